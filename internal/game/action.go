@@ -13,6 +13,15 @@ const (
 	// ActionCharacter executes the character's special action. See
 	// DukeAction, CaptainAction, AmbassadorAction, AssassinAction.
 	ActionCharacter
+	// ActionConvert changes the faction of the author, for 1 coin, or of
+	// their target, for 2 coins. The coins go to the Treasury Reserve.
+	// See ConvertAction and WithReformation.
+	ActionConvert
+	// ActionEmbezzle takes every coin out of the Treasury Reserve. The
+	// author claims *not* to have a Duke; the claim is challenged like any
+	// other but the author proves it by revealing their cards. See
+	// EmbezzleAction and WithReformation.
+	ActionEmbezzle
 )
 
 const (
@@ -23,54 +32,109 @@ const (
 	ActionClaimPassed
 	ActionClaimChallenge
 	ActionClaimProof
+	// ActionClaimTakeCard is appended to the history once a proven card
+	// has been shuffled back into the deck. Cards holds the card drawn in
+	// its place, and AssassinPlace the place of both in the player's hand.
+	//
+	// Do note: ActionClaimTakeCard is private to its author. See
+	//          Game.HistoryFor.
 	ActionClaimTakeCard
 	ActionClaimPunishment
+	// ActionGameOver is appended to the history once only one player is
+	// left alive. AuthorID holds the index of the winner.
+	ActionGameOver
+	// ActionDeal is appended to the history for every player that was
+	// dealt their starting hand. Cards holds the dealt cards.
+	ActionDeal
+	// ActionInfluenceLoss is appended to the history once a player has
+	// lost an influence. Character holds the revealed card and
+	// AssassinPlace its place in the player's hand.
+	ActionInfluenceLoss
+	// ActionReactionPass is appended to the history once a player has
+	// passed during a reaction window. AgainstID holds the player they
+	// let through. See Game.Pass.
+	ActionReactionPass
+	// ActionHandSelection is appended to the history for both players of
+	// the two-player variant once they have picked their card. Cards
+	// holds their starting hand; the picked card then the drawn one.
+	//
+	// Do note: ActionHandSelection is private to its author. See
+	//          Game.HistoryFor.
+	ActionHandSelection
+	// ActionShowCard is appended to the history once the target of an
+	// Inquisitor has shown a card. AgainstID holds the Inquisitor, Cards
+	// the shown card and AssassinPlace its place.
+	//
+	// Do note: ActionShowCard is private to its author and the
+	//          Inquisitor. See Game.HistoryFor.
+	ActionShowCard
+	// ActionExamine is appended to the history once the Inquisitor has
+	// decided. AgainstID holds their target, and AssassinPlace the place
+	// of the card the target swapped; nil if the target keeps it.
+	ActionExamine
+	// ActionExchangeDraw is appended to the history once the cards of an
+	// exchange have been drawn. Character holds the exchanging character
+	// and Cards the drawn cards. See Game.ExchangePool.
+	//
+	// Do note: ActionExchangeDraw is private to its author. See
+	//          Game.HistoryFor.
+	ActionExchangeDraw
+)
+
+const (
+	// variantStartingCoins is the amount of coins the starting player of
+	// the two-player variant starts with. See WithTwoPlayerVariant.
+	variantStartingCoins uint8 = 1
+	// defaultTreasury is the amount of coins the treasury holds before
+	// the starting coins are dealt. See WithTreasury.
+	defaultTreasury uint8 = 50
 )
 
 // coinsPlus is a function that adds an amount (plus) to the original
-// amount of coins (coins). If coins equals 10 or more; then the function
-// returns coins as is.
+// amount of coins (coins). If the sum doesn't fit in an uint8, then the
+// function returns the biggest uint8 instead.
+//
+// Do note: coinsPlus doesn't cap coins at 10. A player with 10 or more
+// coins is forced to Coup by Game instead. See RuleSet.CoinCap.
 func coinsPlus(coins uint8, plus uint8) uint8 {
-	if coins >= 10 {
-		return coins
+	if coins > ^uint8(0)-plus {
+		return ^uint8(0)
 	}
 
 	return coins + plus
 }
 
 // IncomeAction is a function that adds 1 to the amount of coins that
-// was supplied to it.
-//
-// If the player has 10 or more coins, then IncomeAction returns the
-// amount of coins it was supplied without modification.
-func IncomeAction(coins uint8) uint8 { return coinsPlus(coins, 1) }
+// was supplied to it. It follows DefaultRules; see RuleSet.IncomeAction.
+func IncomeAction(coins uint8) uint8 { return DefaultRules().IncomeAction(coins) }
 
 // FinancialAidAction is virtually the same as IncomeAction with two
 // exceptions:
 // - It gives the player 2 coins instead of 1
 // - This action is stoppable by the Duke.
-func FinancialAidAction(coins uint8) uint8 { return coinsPlus(coins, 2) }
+func FinancialAidAction(coins uint8) uint8 { return DefaultRules().FinancialAidAction(coins) }
 
 // DukeAction is virtually the same as IncomeAction with two exceptions:
 // - It gives the player 3 coins instead of 1
 // - This action is stoppable by Challenging the player's claim.
-func DukeAction(coins uint8) uint8 { return coinsPlus(coins, 3) }
+func DukeAction(coins uint8) uint8 { return DefaultRules().DukeAction(coins) }
 
-// removeFromHand essentially sets hand[place] to EmptyCard. If
-// hand[place] is already EmptyCard, set the other index to EmptyCard.
+// removeFromHand essentially reveals hand[place]. If hand[place] is
+// already EmptyCard or revealed, reveal the other index instead.
+//
+// Do note: A revealed card stays in the Hand; see Hand.IsRevealed.
 func removeFromHand(place uint8, hand Hand) Hand {
 	newHand := Hand{hand[0], hand[1]}
-	if newHand[place] == CardEmpty {
-		switch place {
-		case 0:
-			newHand[1] = CardEmpty
-		case 1:
-			newHand[0] = CardEmpty
-		}
-	} else {
-		newHand[place] = CardEmpty
+	places := newHand.places()
+	if len(places) == 0 {
+		return newHand
 	}
 
+	if newHand[place] == CardEmpty || newHand.IsRevealed(place) {
+		place = places[0]
+	}
+	newHand[place] |= cardRevealed
+
 	return newHand
 }
 
@@ -93,14 +157,16 @@ func minusCoinsRemoveFromHand(minus, coins uint8, place uint8, hand Hand) (uint8
 //
 // For example, if place was set to 0 and Hand was
 // {CardContessa, CardAmbassador} it becomes
-// {CardEmpty, CardAmbassador}.
+// {CardContessa | cardRevealed, CardAmbassador}.
 //
 // This function does not affect the data if one of the conditions apply:
 // - Coins is less than 7
 // - Place is more than 1
 // - Hand is already empty
+//
+// CoupAction follows DefaultRules; see RuleSet.CoupAction.
 func CoupAction(coins uint8, place uint8, hand Hand) (uint8, Hand) {
-	return minusCoinsRemoveFromHand(7, coins, place, hand)
+	return DefaultRules().CoupAction(coins, place, hand)
 }
 
 // AssassinAction is the same as CoupAction but with three exceptions:
@@ -108,50 +174,124 @@ func CoupAction(coins uint8, place uint8, hand Hand) (uint8, Hand) {
 // - The player must claim to have an Assassin card
 // - This action is counterable by challenging the player's claim or
 //   through claiming to have a Contessa card.
+//
+// AssassinAction follows DefaultRules; see RuleSet.AssassinAction.
 func AssassinAction(coins uint8, place uint8, hand Hand) (uint8, Hand) {
-	return minusCoinsRemoveFromHand(3, coins, place, hand)
+	return DefaultRules().AssassinAction(coins, place, hand)
 }
 
 // CaptainAction is a function that steals coins from another player.
 // If the target has 2 or more coins, the Captain steals 2. If they have
 // 1, the Captain steals 1. If they have zero, the Captain doesn't steal
-// anything.
+// anything. It follows DefaultRules; see RuleSet.CaptainAction.
 func CaptainAction(captainCoins uint8, targetCoins uint8) (uint8, uint8) {
-	diff := int(targetCoins) - 2
-	if diff < 0 {
-		diff = 0
+	return DefaultRules().CaptainAction(captainCoins, targetCoins)
+}
+
+// exchangeRest is a function that takes the cards the player wants to
+// keep out of the pool made of their live cards and the drawn ones. It
+// returns the cards left in the pool. Empty places of drawn are skipped.
+//
+// ok is false if keep doesn't hold exactly one card per influence, or if
+// one of its cards isn't in the pool.
+func exchangeRest(keep []uint8, hand Hand, drawn Hand) (rest []uint8, ok bool) {
+	places := hand.places()
+	if len(keep) != len(places) {
+		return nil, false
+	}
+
+	rest = []uint8{}
+	for _, place := range drawn.places() {
+		rest = append(rest, drawn[place])
+	}
+	for _, place := range places {
+		rest = append(rest, hand[place])
+	}
+
+	for _, v := range keep {
+		found := false
+		for k := range rest {
+			if rest[k] == v {
+				rest = append(rest[:k], rest[k+1:]...)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, false
+		}
 	}
 
-	return captainCoins + (targetCoins - uint8(diff)), uint8(diff)
+	return rest, true
 }
 
-// AmbassadorAction is a function that swaps or doesn't, depending on
-// what the player chooses, cards from the deck to the player's hand.
+// AmbassadorAction is a function that exchanges the player's live cards
+// with the two cards drawn from the deck.
+//
+// Essentially, keep holds the cards the player wants to keep out of their
+// live cards and the drawn ones; one card per influence. The kept cards
+// take the places of the live cards in order, while the remaining cards
+// are returned in copyDrawn so that they could be put back in the deck.
 //
-// Essentially, the value of places controls which cards are swapped and
-// which stay. If places[0] isn't 2 or more, then it takes places[0] from
-// the deck and swaps it with the player's hand. The same goes for
-// places[1].
+// For example, if keep was {CardDuke}, hand was
+// {CardContessa | cardRevealed, CardCaptain} and drawn was
+// {CardDuke, CardAssassin}, the hand becomes
+// {CardContessa | cardRevealed, CardDuke} and copyDrawn becomes
+// {CardAssassin, CardCaptain}.
 //
-// Do note: AmbassadorAction does not mutate the underlying hand and deck
+// This function does not affect the data if keep is invalid. See
+// exchangeRest.
+//
+// Do note: AmbassadorAction does not mutate the underlying hand and drawn
 //          values.
-func AmbassadorAction(places [2]uint8, hand Hand, deck Hand) (copyHand Hand, copyDeck Hand) {
+func AmbassadorAction(keep []uint8, hand Hand, drawn Hand) (copyHand Hand, copyDrawn Hand) {
 	copyHand = Hand{hand[0], hand[1]}
-	copyDeck = Hand{deck[0], deck[1]}
-	// avoid duplicate card
-	if places[0] == places[1] && places[0] <= 1 {
+	copyDrawn = Hand{drawn[0], drawn[1]}
+
+	rest, ok := exchangeRest(keep, hand, drawn)
+	if !ok {
 		return
 	}
 
-	if places[0] < 2 {
-		copyHand[0], copyDeck[places[0]] = copyDeck[places[0]], copyHand[0]
+	for k, place := range hand.places() {
+		copyHand[place] = keep[k]
 	}
+	copy(copyDrawn[:], rest)
+
+	return
+}
+
+// InquisitorAction is the same as AmbassadorAction but with a single card
+// drawn from the deck. It returns the card that goes back to the deck.
+//
+// For example, if keep was {CardDuke, CardCaptain}, hand was
+// {CardContessa, CardCaptain} and drawn was CardDuke, the hand becomes
+// {CardDuke, CardCaptain} and CardContessa is returned.
+func InquisitorAction(keep []uint8, hand Hand, drawn uint8) (Hand, uint8) {
+	copyHand, copyDrawn := AmbassadorAction(keep, hand, Hand{drawn})
+	return copyHand, copyDrawn[0]
+}
 
-	if places[1] < 2 {
-		copyHand[1], copyDeck[places[1]] = copyDeck[places[1]], copyHand[1]
+// ConvertAction is a function that returns the other faction of the
+// Reformation expansion. FactionNone is returned as is.
+func ConvertAction(faction uint8) uint8 {
+	switch faction {
+	case FactionLoyalist:
+		return FactionReformist
+	case FactionReformist:
+		return FactionLoyalist
 	}
 
-	return
+	return faction
+}
+
+// EmbezzleAction is a function that moves every coin of the Treasury
+// Reserve (reserve) to the player's coins. It returns the new amount of
+// coins and the new reserve. It follows DefaultRules; see
+// RuleSet.EmbezzleAction.
+func EmbezzleAction(coins uint8, reserve uint8) (uint8, uint8) {
+	return DefaultRules().EmbezzleAction(coins, reserve)
 }
 
 func ClaimPunishmentAction(place uint8, hand Hand) Hand {
@@ -161,6 +301,7 @@ func ClaimPunishmentAction(place uint8, hand Hand) Hand {
 
 // Action is a structure of an action that's used to both manipulate
 // the game programmatically, and to store actual Actions in databases.
+// A stored history rebuilds its Game through Replay.
 //
 // Action does not provide a specific method for saving, deleting, or
 // even creating Databases for itself. Instead, it is left for outside
@@ -170,15 +311,18 @@ func ClaimPunishmentAction(place uint8, hand Hand) Hand {
 // IncomeAction and so on. It mutates anything important that relates
 // to the Action's functionality.
 //
-// There are 4 types of actions:
+// There are 6 types of actions:
 // - ActionIncome
 // - ActionFinancialAid
 // - ActionCoup
 // - ActionCharacter
+// - ActionConvert
+// - ActionEmbezzle
 //
-// The reason this is seperated into 4 types instead of 3 + 5
-// (5 = character amount) is because characters have to be claimed
-// first. Only if that claim pass does the actual Action gets executed.
+// The last two are only available with the Reformation expansion. The
+// reason the characters share ActionCharacter instead of having a type
+// each is because characters have to be claimed first. Only if that
+// claim pass does the actual Action gets executed.
 //
 // Besides adding for claim functionality within Action would only
 // complicate the package more than it has to be. This is a design choice
@@ -193,18 +337,34 @@ type Action struct {
 	// Action specific fields; nullable
 	// Used for assassin's action and coup
 	AssassinPlace *uint8 `json:"assassin_place"`
-	// Used for ambassador. Place denotes what to swap
-	// and Hand denotes the two cards drawn from the deck.
-	AmbassadorPlace Hand `json:"ambassador_place"`
-	AmbassadorHand  Hand `json:"ambassador_hand"`
+	// Used for ambassador. Hand denotes the two cards drawn from the
+	// deck; it is set by the Game. See Game.ExchangePool. An Inquisitor
+	// only draws the first card.
+	AmbassadorHand Hand `json:"ambassador_hand"`
+	// Cards holds the cards that were given to AuthorID; like in
+	// ActionDeal. For the ambassador, it holds the cards the player
+	// keeps. See AmbassadorAction.
+	//
+	// For the proof of an inverted claim, it holds the cards the author
+	// revealed. See ActionEmbezzle.
+	Cards []uint8 `json:"cards,omitempty"`
+	// Inverted is only set on the history of an inverted claim; a claim
+	// *not* to have Character. See ActionEmbezzle.
+	Inverted bool `json:"inverted,omitempty"`
+	// Declared is only set on the history of a primary Action that was
+	// just declared; the Action is stored again once it is done, while a
+	// blocked one never is. See Game.Action.
+	Declared bool `json:"declared,omitempty"`
 }
 
 var (
-	ErrInvalidActionAuthor     = fmt.Errorf("author: %w", ErrInvalidPlayer)
-	ErrInvalidActionAgainst    = fmt.Errorf("against: %w", ErrInvalidPlayer)
-	ErrInvalidActionSamePlayer = fmt.Errorf("author and against are the same player")
-	ErrInvalidActionPlace      = fmt.Errorf("place must be [0, 1]")
-	ErrInvalidActionKind       = fmt.Errorf("kind cannot be zero or bigger than ActionCharacter unless it is ActionClaimPunishment")
+	ErrInvalidActionAuthor      = fmt.Errorf("author: %w", ErrInvalidPlayer)
+	ErrInvalidActionAgainst     = fmt.Errorf("against: %w", ErrInvalidPlayer)
+	ErrInvalidActionSamePlayer  = fmt.Errorf("author and against are the same player")
+	ErrInvalidActionPlace       = fmt.Errorf("place must be [0, 1]")
+	ErrInvalidActionKind        = fmt.Errorf("kind cannot be zero or bigger than ActionEmbezzle unless it is ActionClaimPunishment")
+	ErrInvalidActionCoins       = fmt.Errorf("author doesn't have enough coins")
+	ErrInvalidActionPlaceChoice = fmt.Errorf("place is chosen by the player losing the influence")
 )
 
 func (a Action) IsValid() error {
@@ -224,44 +384,167 @@ func (a Action) IsValid() error {
 		}
 	}
 
-	if (a.Kind == 0 || a.Kind > ActionCharacter) && a.Kind != ActionClaimPunishment {
+	if (a.Kind == 0 || a.Kind > ActionEmbezzle) && a.Kind != ActionClaimPunishment {
 		return ErrInvalidActionKind
 	}
 
 	return nil
 }
 
+// characterOf returns the Character of the Action. ok is false unless the
+// Action is the Action of a registered character.
+func characterOf(a Action) (c Character, ok bool) {
+	if a.Kind != ActionCharacter {
+		return nil, false
+	}
+
+	return CharacterOf(a.Character)
+}
+
+// isTargeted returns true if the Action must have a target; i.e. a Coup
+// or the Action of a character that only acts with a target. See
+// Character.Acts.
+func isTargeted(a Action) bool {
+	if a.Kind == ActionCoup {
+		return true
+	}
+
+	c, ok := characterOf(a)
+	return ok && c.Acts(true) && !c.Acts(false)
+}
+
+// validTarget returns true if the Action has a target only when it
+// may have one; i.e. a Coup, a Conversion or the Action of a character
+// that acts with a target. The Action of a character without a target
+// must be one the character has. See Character.Acts.
+func validTarget(a Action) bool {
+	switch a.Kind {
+	case ActionCoup, ActionConvert:
+		return true
+	case ActionCharacter:
+		c, ok := CharacterOf(a.Character)
+		return ok && c.Acts(a.AgainstID != nil)
+	}
+
+	return a.AgainstID == nil
+}
+
+// draws returns the amount of cards the author of the Action draws to
+// exchange with their hand. See Character.Draws.
+func draws(a Action) uint8 {
+	if c, ok := characterOf(a); ok {
+		return c.Draws(a)
+	}
+
+	return 0
+}
+
+// isExchange returns true if the Action exchanges cards of its author
+// with the deck; i.e. an Ambassador or an Inquisitor without a target.
+func isExchange(a Action) bool {
+	return draws(a) > 0
+}
+
+// drawsOnClaim returns true if the only Action of the character is an
+// exchange, whose cards are drawn as soon as its claim holds up; i.e. the
+// Ambassador.
+func drawsOnClaim(character uint8) bool {
+	c, ok := CharacterOf(character)
+	return ok && !c.Acts(true) && c.Draws(Action{Kind: ActionCharacter, Character: character}) > 0
+}
+
+// isExamination returns true if the author of the Action examines their
+// target; i.e. an Inquisitor with a target. See Character.Examines.
+func isExamination(a Action) bool {
+	c, ok := characterOf(a)
+	return ok && c.Examines(a)
+}
+
+// takesInfluence returns true if the target of the Action loses an
+// influence; i.e. a Coup or an assassination. See
+// Character.TakesInfluence.
+func takesInfluence(a Action) bool {
+	if a.Kind == ActionCoup {
+		return true
+	}
+
+	c, ok := characterOf(a)
+	return ok && c.TakesInfluence(a)
+}
+
+// actionCost returns the amount of coins the author has to pay for the
+// Action under the RuleSet. See Character.Cost.
+func actionCost(r RuleSet, a Action) uint8 {
+	switch {
+	case a.Kind == ActionCoup:
+		return r.CoupCost
+	case a.Kind == ActionCharacter:
+		if c, ok := CharacterOf(a.Character); ok {
+			return c.Cost(r)
+		}
+	case a.Kind == ActionConvert && a.AgainstID == nil:
+		return r.ConvertCost
+	case a.Kind == ActionConvert:
+		return r.ConvertOtherCost
+	}
+
+	return 0
+}
+
 // do executes the underlying action if it matches; or does nothing silently.
+// The Action of a character is executed by its Character. See
+// Character.Do.
 // Essentially, it connects parameters & functionas together to mutate
 // underlying player data.
 //
 // For example, say a player wanted to execute the IncomeAction, do would
 // execute the function and mutate the player's coin amount to that of
-// Income.
-func (a *Action) do() {
+// Income. Every amount comes from the RuleSet r.
+//
+// A Coup or an assassination without an AssassinPlace only makes the
+// author pay; the target chooses which card to lose through the Game.
+// The coins paid for a Conversion go to the Treasury Reserve, which an
+// Embezzlement empties; the Game moves those coins. See Game.settle. An
+// Inquisitor with a target does nothing either; the Game lets them
+// examine the target. See Game.Examine.
+func (a *Action) do(r RuleSet) {
 	switch a.Kind {
 	case ActionIncome:
-		a.author.Coins = IncomeAction(a.author.Coins)
+		a.author.Coins = r.IncomeAction(a.author.Coins)
 	case ActionCoup:
-		a.author.Coins, a.against.Hand = CoupAction(a.author.Coins, *a.AssassinPlace, a.against.Hand)
+		if a.AssassinPlace == nil {
+			a.author.Coins -= r.CoupCost
+			break
+		}
+
+		a.author.Coins, a.against.Hand = r.CoupAction(a.author.Coins, *a.AssassinPlace, a.against.Hand)
 	case ActionFinancialAid:
-		a.author.Coins = FinancialAidAction(a.author.Coins)
+		a.author.Coins = r.FinancialAidAction(a.author.Coins)
+	case ActionConvert:
+		a.author.Coins -= actionCost(r, *a)
+
+		target := a.author
+		if a.against != nil {
+			target = a.against
+		}
+		target.Faction = ConvertAction(target.Faction)
 	case ActionCharacter:
-		switch a.Character {
-		case CardAssassin:
-			a.author.Coins, a.against.Hand = AssassinAction(a.author.Coins, *a.AssassinPlace, a.against.Hand)
-		case CardDuke:
-			a.author.Coins = DukeAction(a.author.Coins)
-		case CardCaptain:
-			a.author.Coins, a.against.Coins = CaptainAction(a.author.Coins, a.against.Coins)
-		case CardAmbassador:
-			a.author.Hand, a.AmbassadorHand = AmbassadorAction(a.AmbassadorPlace, a.author.Hand, a.AmbassadorHand)
+		if c, ok := CharacterOf(a.Character); ok {
+			c.Do(r, a)
 		}
 	case ActionClaimPunishment:
 		a.against.Hand = ClaimPunishmentAction(*a.AssassinPlace, a.against.Hand)
 	}
 }
 
+// Author returns the player who made the Action. It is only set once the
+// Game has accepted the Action. See Character.Do.
+func (a *Action) Author() *Player { return a.author }
+
+// Against returns the target of the Action, if any. It is only set once
+// the Game has accepted the Action. See Character.Do.
+func (a *Action) Against() *Player { return a.against }
+
 // setPlayer tries to find the players via AuthorID and AgainstID and sets
 // action and against to the players it found respectively.
 func (a *Action) setPlayer(players []*Player) error {
@@ -287,6 +570,9 @@ func (a *Action) setPlayer(players []*Player) error {
 	return nil
 }
 
+// validClaim returns an error unless the Action is the one the claim c
+// allows; i.e. the Action of its character once it has passed or has
+// been proven.
 func (a Action) validClaim(c *claim) error {
 	if c == nil {
 		return ErrInvalidClaim
@@ -299,16 +585,34 @@ func (a Action) validClaim(c *claim) error {
 		return ErrInvalidActionFrozen
 	} else if a.Character != c.character {
 		return ErrInvalidCharacter
+	} else if a.Kind != ActionCharacter {
+		return ErrInvalidAction
 	}
 
 	return nil
 }
 
+// IsValidCounterAction returns true if the Action b counters the Action a.
+//
+// b must be the Action of a Character that blocks a; see Character.Blocks.
+// Foreign aid can be countered by anyone's Duke. The actions of a Captain
+// and an Assassin can only be countered by their target; so b.AuthorID
+// must equal a.AgainstID.
 func IsValidCounterAction(a Action, b Action) bool {
-	if b.Kind == ActionCharacter {
-		return (a.Kind == ActionFinancialAid && b.Character == CardDuke) ||
-			(a.Kind == ActionCharacter && IsValidCounterClaim(a.Character, b.Character))
+	if b.Kind != ActionCharacter {
+		return false
+	}
+
+	c, ok := CharacterOf(b.Character)
+	return ok && c.Blocks(a) && isValidBlocker(a, b.AuthorID)
+}
+
+// isValidBlocker returns true if the player at index is allowed to block
+// the Action; i.e. the Action isn't targeted or the player is its target.
+func isValidBlocker(a Action, index uint8) bool {
+	if !isTargeted(a) {
+		return true
 	}
 
-	return false
+	return a.AgainstID != nil && *a.AgainstID == index
 }
@@ -15,25 +15,27 @@ func TestCoinsPlus(t *testing.T) {
 	is.Equal(coinsPlus(0, 5), uint8(5))
 
 	is.Equal(coinsPlus(10, 0), uint8(10))
-	is.Equal(coinsPlus(11, 5), uint8(11))
+	is.Equal(coinsPlus(11, 5), uint8(16))
+	is.Equal(coinsPlus(254, 1), uint8(255))
+	is.Equal(coinsPlus(254, 2), uint8(255))
 }
 
 func TestIncomeAction(t *testing.T) {
 	is := is.New(t)
 	is.Equal(IncomeAction(0), uint8(1))
-	is.Equal(IncomeAction(10), uint8(10))
+	is.Equal(IncomeAction(10), uint8(11))
 }
 
 func TestFinancialAidAction(t *testing.T) {
 	is := is.New(t)
 	is.Equal(FinancialAidAction(0), uint8(2))
-	is.Equal(FinancialAidAction(10), uint8(10))
+	is.Equal(FinancialAidAction(10), uint8(12))
 }
 
 func TestDukeAction(t *testing.T) {
 	is := is.New(t)
 	is.Equal(DukeAction(0), uint8(3))
-	is.Equal(DukeAction(10), uint8(10))
+	is.Equal(DukeAction(10), uint8(13))
 }
 
 func onlyHand(coins uint8, hand Hand) Hand   { return hand }
@@ -42,10 +44,14 @@ func onlyCoins(coins uint8, hand Hand) uint8 { return coins }
 func TestRemoveAt(t *testing.T) {
 	is := is.New(t)
 
-	is.Equal(removeFromHand(0, Hand{CardContessa, CardAmbassador}), Hand{CardEmpty, CardAmbassador})
-	is.Equal(removeFromHand(1, Hand{CardAmbassador, CardContessa}), Hand{CardAmbassador, CardEmpty})
-	is.Equal(removeFromHand(0, Hand{CardEmpty, CardAmbassador}), Hand{CardEmpty, CardEmpty})
-	is.Equal(removeFromHand(1, Hand{CardAmbassador, CardEmpty}), Hand{CardEmpty, CardEmpty})
+	is.Equal(removeFromHand(0, Hand{CardContessa, CardAmbassador}), Hand{CardContessa | cardRevealed, CardAmbassador})
+	is.Equal(removeFromHand(1, Hand{CardAmbassador, CardContessa}), Hand{CardAmbassador, CardContessa | cardRevealed})
+	is.Equal(removeFromHand(0, Hand{CardEmpty, CardAmbassador}), Hand{CardEmpty, CardAmbassador | cardRevealed})
+	is.Equal(removeFromHand(1, Hand{CardAmbassador, CardEmpty}), Hand{CardAmbassador | cardRevealed, CardEmpty})
+	is.Equal(removeFromHand(0, Hand{CardDuke | cardRevealed, CardAmbassador}), Hand{CardDuke | cardRevealed, CardAmbassador | cardRevealed})
+
+	revealed := Hand{CardDuke | cardRevealed, CardEmpty}
+	is.Equal(removeFromHand(1, revealed), revealed)
 }
 
 func TestMinusAndRemove(t *testing.T) {
@@ -103,6 +109,35 @@ func TestAssassinAction(t *testing.T) {
 	is.Equal(wantHand, haveHand)
 }
 
+func TestInquisitorAction(t *testing.T) {
+	is := is.New(t)
+
+	hand, rest := InquisitorAction([]uint8{CardDuke, CardCaptain}, Hand{CardContessa, CardCaptain}, CardDuke)
+	is.Equal(hand, Hand{CardDuke, CardCaptain})
+	is.Equal(rest, CardContessa)
+
+	// invalid keeps change nothing
+	hand, rest = InquisitorAction([]uint8{CardDuke, CardDuke}, Hand{CardContessa, CardCaptain}, CardDuke)
+	is.Equal(hand, Hand{CardContessa, CardCaptain})
+	is.Equal(rest, CardDuke)
+}
+
+func TestConvertAction(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(ConvertAction(FactionLoyalist), FactionReformist)
+	is.Equal(ConvertAction(FactionReformist), FactionLoyalist)
+	is.Equal(ConvertAction(FactionNone), FactionNone)
+}
+
+func TestEmbezzleAction(t *testing.T) {
+	is := is.New(t)
+
+	coins, reserve := EmbezzleAction(2, 5)
+	is.Equal(coins, uint8(7))
+	is.Equal(reserve, uint8(0))
+}
+
 func TestClaimPunishmentAction(t *testing.T) {
 	is := is.New(t)
 	place, hand := uint8(0), Hand{CardContessa, CardAmbassador}
@@ -129,70 +164,88 @@ func TestCaptainAction(t *testing.T) {
 	coins, other = CaptainAction(4, 3)
 	is.True(coins == 6)
 	is.True(other == 1)
+
+	coins, other = CaptainAction(10, 2)
+	is.True(coins == 12)
+	is.True(other == 0)
+}
+
+func TestExchangeRest(t *testing.T) {
+	is := is.New(t)
+
+	hand, drawn := Hand{CardAmbassador, CardContessa}, Hand{CardDuke, CardDuke}
+
+	rest, ok := exchangeRest([]uint8{CardDuke, CardContessa}, hand, drawn)
+	is.True(ok)
+	is.Equal(rest, []uint8{CardDuke, CardAmbassador})
+
+	rest, ok = exchangeRest([]uint8{CardDuke, CardDuke}, hand, drawn)
+	is.True(ok)
+	is.Equal(rest, []uint8{CardAmbassador, CardContessa})
+
+	// empty places of drawn are skipped
+	rest, ok = exchangeRest([]uint8{CardDuke, CardContessa}, hand, Hand{CardDuke})
+	is.True(ok)
+	is.Equal(rest, []uint8{CardAmbassador})
+
+	_, ok = exchangeRest([]uint8{CardEmpty, CardContessa}, hand, Hand{CardDuke})
+	is.True(!ok)
+
+	for _, keep := range [][]uint8{
+		nil,
+		{CardDuke},
+		{CardDuke, CardDuke, CardDuke},
+		{CardContessa, CardContessa},
+		{CardAssassin, CardDuke},
+	} {
+		_, ok = exchangeRest(keep, hand, drawn)
+		is.True(!ok)
+	}
+
+	// a revealed card isn't part of the pool
+	hand[0] |= cardRevealed
+	rest, ok = exchangeRest([]uint8{CardDuke}, hand, drawn)
+	is.True(ok)
+	is.Equal(rest, []uint8{CardDuke, CardContessa})
+
+	_, ok = exchangeRest([]uint8{CardAmbassador}, hand, drawn)
+	is.True(!ok)
 }
 
 func TestAmbassadorAction(t *testing.T) {
 	currentHand := Hand{CardAmbassador, CardContessa}
-	nextHand := Hand{CardDuke, CardAssassin}
+	drawn := Hand{CardDuke, CardAssassin}
 
 	is := is.New(t)
 
-	wantDeck := Hand{nextHand[0], nextHand[1]}
-	wantHand := Hand{currentHand[0], currentHand[1]}
-	isEqual := func(one, two uint8) {
-		haveHand, haveDeck := AmbassadorAction([2]uint8{one, two}, currentHand, nextHand)
+	// nothing changes on an invalid keep
+	hand, rest := AmbassadorAction([]uint8{CardCaptain, CardDuke}, currentHand, drawn)
+	is.Equal(hand, currentHand)
+	is.Equal(rest, drawn)
 
-		is.Equal(wantHand, haveHand)
-		is.Equal(wantDeck, haveDeck)
-	}
+	hand, rest = AmbassadorAction([]uint8{CardAmbassador, CardContessa}, currentHand, drawn)
+	is.Equal(hand, currentHand)
+	is.Equal(rest, drawn)
 
-	// 2, 1
-	isEqual(2, 2)
+	hand, rest = AmbassadorAction([]uint8{CardAssassin, CardAmbassador}, currentHand, drawn)
+	is.Equal(hand, Hand{CardAssassin, CardAmbassador})
+	is.Equal(rest, Hand{CardDuke, CardContessa})
 	// must not mutate
 	is.Equal(currentHand, Hand{CardAmbassador, CardContessa})
+	is.Equal(drawn, Hand{CardDuke, CardAssassin})
 
-	// 0, 1
-	wantDeck = Hand{currentHand[0], currentHand[1]}
-	wantHand = Hand{nextHand[0], nextHand[1]}
-	//is.Equal(want, AmbassadorAction([2]uint8{0, 1}, currentHand, nextHand))
-	isEqual(0, 1)
-
-	// 0, 2
-	wantDeck = Hand{currentHand[0], nextHand[1]}
-	wantHand = Hand{nextHand[0], currentHand[1]}
-	isEqual(0, 2)
-
-	// 1, 2
-	wantDeck = Hand{nextHand[0], currentHand[0]}
-	wantHand = Hand{nextHand[1], currentHand[1]}
-	isEqual(1, 2)
-
-	// 1, 1
-	wantDeck = nextHand
-	wantHand = currentHand
-	isEqual(1, 1)
-
-	// 1, 0
-	wantDeck = Hand{currentHand[1], currentHand[0]}
-	wantHand = Hand{nextHand[1], nextHand[0]}
-	isEqual(1, 0)
-
-	// 2, 1
-	wantDeck = Hand{nextHand[0], currentHand[1]}
-	wantHand = Hand{currentHand[0], nextHand[1]}
-	isEqual(2, 1)
-
-	// 2, 0
-	wantDeck = Hand{currentHand[1], nextHand[1]}
-	wantHand = Hand{currentHand[0], nextHand[0]}
-	isEqual(2, 0)
+	// a single influence keeps a single card
+	currentHand[0] |= cardRevealed
+	hand, rest = AmbassadorAction([]uint8{CardDuke}, currentHand, drawn)
+	is.Equal(hand, Hand{CardAmbassador | cardRevealed, CardDuke})
+	is.Equal(rest, Hand{CardAssassin, CardContessa})
 }
 
 func TestActionDo(t *testing.T) {
 	player := &Player{}
 
 	a := &Action{Kind: ActionIncome, author: player}
-	a.do()
+	a.do(DefaultRules())
 
 	is := is.New(t)
 	is.Equal(player.Coins, uint8(1))
@@ -210,13 +263,22 @@ func TestActionDo(t *testing.T) {
 
 	coins, hand := CoupAction(player.Coins, val, target.Hand)
 	a.AssassinPlace = &val
-	a.do()
+	a.do(DefaultRules())
 
 	is.Equal(target.Hand, hand)
 	is.Equal(player.Coins, coins)
 
+	// the target chooses which card to lose
+	player.Coins, a.AssassinPlace = 8, nil
+	hand = target.Hand
+	a.do(DefaultRules())
+
+	is.Equal(target.Hand, hand)
+	is.Equal(player.Coins, uint8(1))
+	player.Coins = 0
+
 	a.Kind = ActionFinancialAid
-	a.do()
+	a.do(DefaultRules())
 	is.Equal(player.Coins, uint8(2))
 
 	// Character specific tests
@@ -229,7 +291,7 @@ func TestActionDo(t *testing.T) {
 		coins := uint8(0)
 
 		player.Coins = coins
-		a.do()
+		a.do(DefaultRules())
 		is.Equal(player.Coins, DukeAction(coins))
 	}
 	// Assassin
@@ -242,7 +304,7 @@ func TestActionDo(t *testing.T) {
 		val := uint8(0)
 
 		a.AssassinPlace = &val
-		a.do()
+		a.do(DefaultRules())
 
 		is.Equal(target.Hand, hand)
 		is.Equal(player.Coins, coins)
@@ -254,7 +316,7 @@ func TestActionDo(t *testing.T) {
 		pCoins, tCoins := CaptainAction(player.Coins, target.Coins)
 
 		a.Character = CardCaptain
-		a.do()
+		a.do(DefaultRules())
 
 		is.Equal(pCoins, player.Coins)
 		is.Equal(tCoins, target.Coins)
@@ -262,13 +324,13 @@ func TestActionDo(t *testing.T) {
 	// Ambassador
 	{
 		hand := Hand{0: CardContessa, 1: CardDuke}
-		places := Hand{0: 0, 1: 1}
+		keep := []uint8{CardDuke, player.Hand[1]}
 
-		newHand, newDeck := AmbassadorAction(places, player.Hand, hand)
+		newHand, newDeck := AmbassadorAction(keep, player.Hand, hand)
 		a.Character = CardAmbassador
-		a.AmbassadorPlace = places
+		a.Cards = keep
 		a.AmbassadorHand = hand
-		a.do()
+		a.do(DefaultRules())
 
 		is.Equal(player.Hand, newHand)
 		is.Equal(a.AmbassadorHand, newDeck)
@@ -284,11 +346,26 @@ func TestActionDo(t *testing.T) {
 
 		a.Kind = ActionClaimPunishment
 		a.AssassinPlace = &val
-		a.do()
+		a.do(DefaultRules())
 
 		is.Equal(target.Hand, hand)
 		is.Equal(player.Coins, coins)
 	}
+	// Convert
+	{
+		player.Coins, player.Faction, target.Faction = 3, FactionLoyalist, FactionLoyalist
+
+		a := &Action{Kind: ActionConvert, author: player}
+		a.do(DefaultRules())
+		is.Equal(player.Coins, 3-DefaultRules().ConvertCost)
+		is.Equal(player.Faction, FactionReformist)
+
+		a.AgainstID, a.against = newUint8(1), target
+		a.do(DefaultRules())
+		is.Equal(player.Coins, 3-DefaultRules().ConvertCost-DefaultRules().ConvertOtherCost)
+		is.Equal(player.Faction, FactionReformist)
+		is.Equal(target.Faction, FactionReformist)
+	}
 }
 
 func TestActionValid(t *testing.T) {
@@ -320,12 +397,15 @@ func TestActionValid(t *testing.T) {
 
 	is.Equal(a.IsValid(), ErrInvalidActionKind)
 
-	a.Kind = ActionCharacter + 1
+	a.Kind = ActionEmbezzle + 1
 	is.Equal(a.IsValid(), ErrInvalidActionKind)
 
 	a.Kind = ActionCharacter
 	is.NoErr(a.IsValid())
 
+	a.Kind = ActionEmbezzle
+	is.NoErr(a.IsValid())
+
 	a.Kind = ActionClaimPunishment
 	is.NoErr(a.IsValid())
 }
@@ -360,7 +440,7 @@ func TestActionSetPlayers(t *testing.T) {
 func TestActionValidClaim(t *testing.T) {
 	is := is.New(t)
 
-	a := Action{}
+	a := Action{Kind: ActionCharacter}
 
 	is.Equal(a.validClaim(nil), ErrInvalidClaim)
 	is.Equal(a.validClaim(&claim{}), ErrInvalidClaimHasNotFinished)
@@ -368,6 +448,57 @@ func TestActionValidClaim(t *testing.T) {
 	is.Equal(a.validClaim(&claim{succeed: new(bool), character: 1}), ErrInvalidCharacter)
 
 	is.NoErr(a.validClaim(&claim{succeed: new(bool), character: 0}))
+
+	// only the character's Action is allowed
+	is.Equal(Action{Kind: ActionFinancialAid}.validClaim(&claim{succeed: new(bool)}), ErrInvalidAction)
+}
+
+func TestIsTargeted(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isTargeted(Action{Kind: ActionCoup}))
+	is.True(isTargeted(Action{Kind: ActionCharacter, Character: CardAssassin}))
+	is.True(isTargeted(Action{Kind: ActionCharacter, Character: CardCaptain}))
+	is.True(!isTargeted(Action{Kind: ActionCharacter, Character: CardDuke}))
+	is.True(!isTargeted(Action{Kind: ActionIncome}))
+}
+
+func TestActionCost(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionCoup}), DefaultRules().CoupCost)
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionCharacter, Character: CardAssassin}), DefaultRules().AssassinCost)
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionCharacter, Character: CardCaptain}), uint8(0))
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionFinancialAid}), uint8(0))
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionConvert}), DefaultRules().ConvertCost)
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionConvert, AgainstID: newUint8(1)}), DefaultRules().ConvertOtherCost)
+	is.Equal(actionCost(DefaultRules(), Action{Kind: ActionEmbezzle}), uint8(0))
+}
+
+func TestIsExchange(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isExchange(Action{Kind: ActionCharacter, Character: CardAmbassador}))
+	is.True(isExchange(Action{Kind: ActionCharacter, Character: CardInquisitor}))
+	is.True(!isExchange(Action{Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}))
+	is.True(!isExchange(Action{Kind: ActionCharacter, Character: CardDuke}))
+}
+
+func TestIsExamination(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isExamination(Action{Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}))
+	is.True(!isExamination(Action{Kind: ActionCharacter, Character: CardInquisitor}))
+	is.True(!isExamination(Action{Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(1)}))
+}
+
+func TestTakesInfluence(t *testing.T) {
+	is := is.New(t)
+
+	is.True(takesInfluence(Action{Kind: ActionCoup}))
+	is.True(takesInfluence(Action{Kind: ActionCharacter, Character: CardAssassin}))
+	is.True(!takesInfluence(Action{Kind: ActionCharacter, Character: CardCaptain}))
+	is.True(!takesInfluence(Action{Kind: ActionConvert, AgainstID: newUint8(1)}))
 }
 
 func TestIsValidCounterAction(t *testing.T) {
@@ -378,13 +509,45 @@ func TestIsValidCounterAction(t *testing.T) {
 		Character: CardDuke,
 	}))
 
-	is.Equal(IsValidCounterAction(Action{
+	// anyone may block foreign aid
+	is.True(IsValidCounterAction(Action{Kind: ActionFinancialAid}, Action{
+		AuthorID:  3,
+		Kind:      ActionCharacter,
+		Character: CardDuke,
+	}))
+
+	assassin := Action{
 		Kind:      ActionCharacter,
 		Character: CardAssassin,
-	}, Action{
+		AgainstID: newUint8(1),
+	}
+	is.Equal(IsValidCounterAction(assassin, Action{
+		AuthorID:  1,
 		Kind:      ActionCharacter,
 		Character: CardContessa,
 	}), IsValidCounterClaim(CardAssassin, CardContessa))
 
+	// only the target may block
+	is.True(!IsValidCounterAction(assassin, Action{
+		AuthorID:  2,
+		Kind:      ActionCharacter,
+		Character: CardContessa,
+	}))
+
+	captain := Action{Kind: ActionCharacter, Character: CardCaptain}
+	is.True(!IsValidCounterAction(captain, Action{Kind: ActionCharacter, Character: CardCaptain}))
+	captain.AgainstID = newUint8(2)
+	is.True(IsValidCounterAction(captain, Action{AuthorID: 2, Kind: ActionCharacter, Character: CardAmbassador}))
+	is.True(!IsValidCounterAction(captain, Action{AuthorID: 1, Kind: ActionCharacter, Character: CardAmbassador}))
+
 	is.True(!IsValidCounterAction(Action{Kind: ActionIncome}, Action{Kind: ActionIncome}))
 }
+
+func TestIsValidBlocker(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isValidBlocker(Action{Kind: ActionFinancialAid}, 2))
+	is.True(isValidBlocker(Action{Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(2)}, 2))
+	is.True(!isValidBlocker(Action{Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(2)}, 1))
+	is.True(!isValidBlocker(Action{Kind: ActionCharacter, Character: CardAssassin}, 0))
+}
@@ -24,10 +24,20 @@ const (
 	//
 	// Do note: An assassination attempt is not the same as a coup.
 	CardContessa
+	// CardInquisitor is a card that either exchanges one card with the
+	// deck, or looks at one card of another player and may make them
+	// swap it with the deck.
+	// CardInquisitor also has a counter action; which is to prevent any
+	// Captain from stealing from them.
+	//
+	// Do note: CardInquisitor replaces CardAmbassador. See
+	//          WithInquisitor.
+	CardInquisitor
 )
 
-// IsValidCard returns true if the value is in between CardAssassin
-// && CardContessa.
+// IsValidCard returns true if the value is the ID of a registered
+// Character. See RegisterCharacter.
 func IsValidCard(v uint8) bool {
-	return v >= CardAssassin && v <= CardContessa
+	_, ok := CharacterOf(v)
+	return ok
 }
@@ -0,0 +1,293 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Character is an interface that describes the behaviour of a card. Every
+// card value accepted by IsValidCard belongs to a registered Character.
+// See RegisterCharacter.
+//
+// The classic characters; CardAssassin, CardDuke, CardAmbassador,
+// CardCaptain, CardContessa and CardInquisitor; are registered by the
+// package itself. House-rule characters can be registered alongside
+// them, and are played in the Games created WithCharacters.
+//
+// Do note: Character only describes the effect of an Action on its
+//          players. The exchange, the examination and the influence loss
+//          it asks for are driven by the Game.
+type Character interface {
+	// ID returns the card value of the Character.
+	ID() uint8
+	// Name returns the human readable name of the Character.
+	Name() string
+	// Cost returns the amount of coins the author of the Character's
+	// Action pays to the treasury under the RuleSet.
+	Cost(r RuleSet) uint8
+	// Payout returns the amount of coins the treasury pays to the author
+	// of the Character's Action under the RuleSet.
+	Payout(r RuleSet) uint8
+	// Acts returns true if the Character has an Action with a target if
+	// targeted is true, or an Action without a target otherwise. A
+	// Character that has neither only blocks.
+	Acts(targeted bool) bool
+	// Blocks returns true if a claim of the Character counters the Action.
+	Blocks(a Action) bool
+	// Draws returns the amount of cards, up to 2, that the author of the
+	// Action draws from the deck to exchange with their hand. See
+	// Game.ExchangePool.
+	Draws(a Action) uint8
+	// TakesInfluence returns true if the target of the Action loses an
+	// influence of their choice. See Game.LoseInfluence.
+	TakesInfluence(a Action) bool
+	// Examines returns true if the author of the Action examines a card of
+	// their target. See Game.Examine.
+	Examines(a Action) bool
+	// Do executes the Character's Action under the RuleSet. See
+	// Action.Author and Action.Against.
+	Do(r RuleSet, a *Action)
+}
+
+var (
+	ErrCharacterRegistered = fmt.Errorf("character is already registered")
+)
+
+var (
+	registry    = map[uint8]Character{}
+	registryMtx sync.RWMutex
+)
+
+// RegisterCharacter is a function that adds the Character to the
+// registry, which makes its ID a valid card.
+//
+// RegisterCharacter returns ErrInvalidCharacter if the ID is CardEmpty or
+// collides with the revealed flag of a Hand, and ErrCharacterRegistered if
+// the ID is already taken.
+//
+// Do note: A registered Character is only part of the deck of the Games
+//          created WithCharacters.
+func RegisterCharacter(c Character) error {
+	registryMtx.Lock()
+	defer registryMtx.Unlock()
+
+	id := c.ID()
+	if id == CardEmpty || id&cardRevealed != 0 {
+		return ErrInvalidCharacter
+	} else if _, ok := registry[id]; ok {
+		return ErrCharacterRegistered
+	}
+
+	registry[id] = c
+	return nil
+}
+
+// CharacterOf returns the registered Character of the card value v. ok is
+// false if v isn't registered.
+func CharacterOf(v uint8) (c Character, ok bool) {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	c, ok = registry[v]
+	return
+}
+
+// characterIDs returns the ID of every registered Character in order.
+func characterIDs() []uint8 {
+	registryMtx.RLock()
+	defer registryMtx.RUnlock()
+
+	arr := []uint8{}
+	for id := range registry {
+		arr = append(arr, id)
+	}
+	sort.Slice(arr, func(i, j int) bool { return arr[i] < arr[j] })
+
+	return arr
+}
+
+// classicRoster returns the characters of the classic deck in order.
+// See WithInquisitor.
+func classicRoster() []uint8 {
+	return []uint8{CardAssassin, CardDuke, CardAmbassador, CardCaptain, CardContessa}
+}
+
+// validRoster returns true if every character of the roster is
+// registered.
+func validRoster(roster []uint8) bool {
+	for _, character := range roster {
+		if !IsValidCard(character) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// classic is a Character made out of plain values. It is used by the
+// characters that the package registers itself.
+//
+// untargeted and targeted tell which Actions the character has. draws,
+// takesInfluence and examines apply to all of them; the Game tells them
+// apart through the target of the Action.
+type classic struct {
+	id         uint8
+	name       string
+	cost       func(r RuleSet) uint8
+	payout     func(r RuleSet) uint8
+	untargeted bool
+	targeted   bool
+	blocks     func(a Action) bool
+	draws      func(a Action) uint8
+	takes      bool
+	examines   func(a Action) bool
+	do         func(r RuleSet, a *Action)
+}
+
+func (c classic) ID() uint8    { return c.id }
+func (c classic) Name() string { return c.name }
+
+func (c classic) Cost(r RuleSet) uint8 {
+	if c.cost == nil {
+		return 0
+	}
+
+	return c.cost(r)
+}
+
+func (c classic) Payout(r RuleSet) uint8 {
+	if c.payout == nil {
+		return 0
+	}
+
+	return c.payout(r)
+}
+
+func (c classic) Acts(targeted bool) bool {
+	if targeted {
+		return c.targeted
+	}
+
+	return c.untargeted
+}
+
+func (c classic) Blocks(a Action) bool {
+	return c.blocks != nil && c.blocks(a)
+}
+
+func (c classic) Draws(a Action) uint8 {
+	if c.draws == nil {
+		return 0
+	}
+
+	return c.draws(a)
+}
+
+func (c classic) TakesInfluence(a Action) bool { return c.takes }
+
+func (c classic) Examines(a Action) bool {
+	return c.examines != nil && c.examines(a)
+}
+
+func (c classic) Do(r RuleSet, a *Action) {
+	if c.do != nil {
+		c.do(r, a)
+	}
+}
+
+// blocksCharacter returns a Blocks function that counters the Action of
+// the character.
+func blocksCharacter(character uint8) func(a Action) bool {
+	return func(a Action) bool {
+		return a.Kind == ActionCharacter && a.Character == character
+	}
+}
+
+// drawsUntargeted returns a Draws function that draws n cards for the
+// Action without a target.
+func drawsUntargeted(n uint8) func(a Action) uint8 {
+	return func(a Action) uint8 {
+		if a.AgainstID != nil {
+			return 0
+		}
+
+		return n
+	}
+}
+
+// classicCharacters returns the characters that the package registers
+// itself.
+func classicCharacters() []Character {
+	return []Character{
+		classic{
+			id:       CardAssassin,
+			name:     "Assassin",
+			cost:     func(r RuleSet) uint8 { return r.AssassinCost },
+			targeted: true,
+			takes:    true,
+			do: func(r RuleSet, a *Action) {
+				if a.AssassinPlace == nil {
+					a.author.Coins -= r.AssassinCost
+					return
+				}
+
+				a.author.Coins, a.against.Hand = r.AssassinAction(a.author.Coins, *a.AssassinPlace, a.against.Hand)
+			},
+		},
+		classic{
+			id:         CardDuke,
+			name:       "Duke",
+			payout:     func(r RuleSet) uint8 { return r.Tax },
+			untargeted: true,
+			blocks:     func(a Action) bool { return a.Kind == ActionFinancialAid },
+			do:         func(r RuleSet, a *Action) { a.author.Coins = r.DukeAction(a.author.Coins) },
+		},
+		classic{
+			id:         CardAmbassador,
+			name:       "Ambassador",
+			untargeted: true,
+			blocks:     blocksCharacter(CardCaptain),
+			draws:      func(a Action) uint8 { return 2 },
+			do: func(r RuleSet, a *Action) {
+				a.author.Hand, a.AmbassadorHand = AmbassadorAction(a.Cards, a.author.Hand, a.AmbassadorHand)
+			},
+		},
+		classic{
+			id:       CardCaptain,
+			name:     "Captain",
+			targeted: true,
+			blocks:   blocksCharacter(CardCaptain),
+			do: func(r RuleSet, a *Action) {
+				a.author.Coins, a.against.Coins = r.CaptainAction(a.author.Coins, a.against.Coins)
+			},
+		},
+		classic{
+			id:     CardContessa,
+			name:   "Contessa",
+			blocks: blocksCharacter(CardAssassin),
+		},
+		classic{
+			id:         CardInquisitor,
+			name:       "Inquisitor",
+			untargeted: true,
+			targeted:   true,
+			blocks:     blocksCharacter(CardCaptain),
+			draws:      drawsUntargeted(1),
+			examines:   func(a Action) bool { return a.AgainstID != nil },
+			do: func(r RuleSet, a *Action) {
+				if a.against == nil {
+					a.author.Hand, a.AmbassadorHand[0] = InquisitorAction(a.Cards, a.author.Hand, a.AmbassadorHand[0])
+				}
+			},
+		},
+	}
+}
+
+func init() {
+	for _, c := range classicCharacters() {
+		if err := RegisterCharacter(c); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// cardBishop is a house-rule character that takes a coin from its target.
+const cardBishop = CardInquisitor + 1
+
+// registerBishop registers cardBishop and returns a function that removes
+// it from the registry again.
+func registerBishop(t *testing.T) func() {
+	err := RegisterCharacter(classic{
+		id:       cardBishop,
+		name:     "Bishop",
+		targeted: true,
+		blocks:   func(a Action) bool { return a.Kind == ActionCoup },
+		do: func(r RuleSet, a *Action) {
+			if a.Against().Coins > 0 {
+				a.Author().Coins, a.Against().Coins = a.Author().Coins+1, a.Against().Coins-1
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		registryMtx.Lock()
+		defer registryMtx.Unlock()
+
+		delete(registry, cardBishop)
+	}
+}
+
+func TestRegisterCharacter(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(RegisterCharacter(classic{id: CardEmpty}), ErrInvalidCharacter)
+	is.Equal(RegisterCharacter(classic{id: CardDuke | cardRevealed}), ErrInvalidCharacter)
+	is.Equal(RegisterCharacter(classic{id: CardDuke}), ErrCharacterRegistered)
+
+	defer registerBishop(t)()
+	is.True(IsValidCard(cardBishop))
+	is.Equal(RegisterCharacter(classic{id: cardBishop}), ErrCharacterRegistered)
+}
+
+func TestCharacterOf(t *testing.T) {
+	is := is.New(t)
+
+	c, ok := CharacterOf(CardDuke)
+	is.True(ok)
+	is.Equal(c.Name(), "Duke")
+	is.Equal(c.Payout(DefaultRules()), uint8(3))
+	is.True(c.Blocks(Action{Kind: ActionFinancialAid}))
+
+	c, ok = CharacterOf(CardAssassin)
+	is.True(ok)
+	is.Equal(c.Cost(DefaultRules()), DefaultRules().AssassinCost)
+	is.True(c.Acts(true) && !c.Acts(false))
+	is.True(c.TakesInfluence(Action{Kind: ActionCharacter, Character: CardAssassin}))
+
+	c, ok = CharacterOf(CardAmbassador)
+	is.True(ok)
+	is.Equal(c.Draws(Action{Kind: ActionCharacter, Character: CardAmbassador}), uint8(2))
+
+	c, ok = CharacterOf(CardInquisitor)
+	is.True(ok)
+	is.True(c.Acts(true) && c.Acts(false))
+	is.Equal(c.Draws(Action{Kind: ActionCharacter, Character: CardInquisitor}), uint8(1))
+	is.Equal(c.Draws(Action{Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}), uint8(0))
+	is.True(c.Examines(Action{Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}))
+
+	c, ok = CharacterOf(CardContessa)
+	is.True(ok)
+	is.True(!c.Acts(true) && !c.Acts(false))
+
+	_, ok = CharacterOf(cardBishop)
+	is.True(!ok)
+}
+
+func TestCharacterIDs(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(characterIDs(), []uint8{CardAssassin, CardDuke, CardAmbassador, CardCaptain, CardContessa, CardInquisitor})
+
+	defer registerBishop(t)()
+	is.Equal(characterIDs()[6], cardBishop)
+}
+
+func TestGameHouseRuleCharacter(t *testing.T) {
+	defer registerBishop(t)()
+
+	is := is.New(t)
+
+	// a registered character is only played by the games asking for it
+	g, err := NewGame([5]*Player{{}, {}})
+	is.NoErr(err)
+	is.True(!g.inPlay(cardBishop))
+	is.True(!g.isBlockable(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}))
+
+	_, err = NewGame([5]*Player{{}, {}}, WithCharacters(cardBishop+1))
+	is.Equal(err, ErrInvalidCharacter)
+
+	pl := [5]*Player{{}, {}}
+	g, err = NewGame(pl, WithCharacters(cardBishop))
+	is.NoErr(err)
+	for k, v := range []Hand{{cardBishop, CardDuke}, {cardBishop, CardContessa}} {
+		g.treasury += pl[k].Coins
+		pl[k].Hand, pl[k].Coins = v, 0
+	}
+	g.players[1].Coins = 2
+
+	// the house-rule character is in play
+	is.Equal(g.characters(), []uint8{CardAssassin, CardDuke, CardAmbassador, CardCaptain, CardContessa, cardBishop})
+	is.True(g.isBlockable(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}))
+
+	is.Equal(g.LegalMoves(0)[len(g.LegalMoves(0))-1], Move{Input: InputAction, Character: cardBishop})
+
+	is.NoErr(g.Claim(g.players[0], cardBishop))
+	is.NoErr(g.ClaimPass())
+
+	steal := Action{AuthorID: 0, Kind: ActionCharacter, Character: cardBishop, AgainstID: newUint8(1)}
+	is.Equal(g.LegalMoves(0), []Move{{Input: InputAction, Action: &steal}})
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: cardBishop}), ErrInvalidActionAgainst)
+
+	is.NoErr(g.Action(steal))
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Coins, uint8(1))
+	is.Equal(g.players[1].Coins, uint8(1))
+
+	// the house-rule character blocks a coup
+	is.True(IsValidCounterAction(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}, Action{AuthorID: 1, Kind: ActionCharacter, Character: cardBishop}))
+}
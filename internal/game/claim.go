@@ -9,17 +9,10 @@ import "sync"
 // Ambassador.
 //
 // IsValidCounterClaim ensures that the data inputted by the user for a
-// counter claim is valid.
+// counter claim is valid. See Character.Blocks.
 func IsValidCounterClaim(character, counter uint8) bool {
-	switch character {
-	case CardAssassin:
-		return counter == CardContessa
-	case CardCaptain:
-		return counter == CardAmbassador ||
-			counter == CardCaptain
-	}
-
-	return false
+	c, ok := CharacterOf(counter)
+	return ok && c.Blocks(Action{Kind: ActionCharacter, Character: character})
 }
 
 // Claim is a data structure that tells the internal packages; "Hey I
@@ -43,11 +36,23 @@ func IsValidCounterClaim(character, counter uint8) bool {
 //       Any counter claim, used to defend the player, should be done
 //       through creating another claim.
 type claim struct {
-	author    *Player
-	character uint8
+	author     *Player
+	challenger *Player
+	character  uint8
+	// inverted is true for a claim *not* to have character. See
+	// ActionEmbezzle.
+	inverted  bool
 	succeed   *bool
 	challenge *bool
-	mtx       sync.Mutex
+	// seq, challengeSeq and proofSeq are the Seq of the entries of the
+	// claim, its challenge and its proof in the history. cause is the Seq
+	// of the entry the claim responds to, if any; the Action a counter
+	// claim blocks. See Entry.Cause.
+	cause        *uint64
+	seq          uint64
+	challengeSeq uint64
+	proofSeq     uint64
+	mtx          sync.Mutex
 }
 
 // NewClaim is a function that creates a valid Claim or return an error.
@@ -158,12 +163,23 @@ func (c *claim) Pass() {
 	}
 }
 
+// challengeOutcome returns the winner and the loser of the claim's
+// challenge. The author only wins if their proof matched the claim.
+func (c *claim) challengeOutcome() (winner *Player, loser *Player) {
+	if succeed, _ := c.Results(); succeed != nil && *succeed {
+		return c.author, c.challenger
+	}
+
+	return c.challenger, c.author
+}
+
 // Action returns an Action that's used to store a Claim in a history
 // array.
 func (c *claim) Action(authorid uint8) Action {
 	a := Action{
 		AuthorID:  authorid,
 		Character: c.character,
+		Inverted:  c.inverted,
 	}
 
 	if c.succeed != nil && c.challenge != nil {
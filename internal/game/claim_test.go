@@ -12,6 +12,7 @@ func TestIsValidCounterClaim(t *testing.T) {
 	is.Equal(IsValidCounterClaim(CardAssassin, CardContessa), true)
 	is.Equal(IsValidCounterClaim(CardCaptain, CardCaptain), true)
 	is.Equal(IsValidCounterClaim(CardCaptain, CardAmbassador), true)
+	is.Equal(IsValidCounterClaim(CardCaptain, CardInquisitor), true)
 	is.Equal(IsValidCounterClaim(CardContessa, CardAssassin), false)
 }
 
@@ -197,3 +198,21 @@ func TestClaimAction(t *testing.T) {
 	c.succeed = c.challenge
 	is.Equal(c.Action(0), newAction(0, ActionClaimProof, CardAmbassador))
 }
+
+func TestClaimChallengeOutcome(t *testing.T) {
+	author, challenger := &Player{}, &Player{}
+	c := &claim{author: author, challenger: challenger}
+
+	is := is.New(t)
+	c.Challenge()
+	c.Prove(true)
+
+	winner, loser := c.challengeOutcome()
+	is.Equal(winner, author)
+	is.Equal(loser, challenger)
+
+	*c.succeed = false
+	winner, loser = c.challengeOutcome()
+	is.Equal(winner, challenger)
+	is.Equal(loser, author)
+}
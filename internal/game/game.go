@@ -14,7 +14,7 @@ var (
 	ErrInvalidParameters          = fmt.Errorf("invalid parameters")
 	ErrInvalidAction              = fmt.Errorf("invalid action")
 	ErrInvalidActionFrozen        = fmt.Errorf("cannot create action because it is frozen by a claim")
-	ErrInvalidPlayerAmount        = fmt.Errorf("players must be 2 to 5")
+	ErrInvalidPlayerAmount        = fmt.Errorf("players must be 2 to %d", maxPlayers)
 	ErrInvalidClaim               = fmt.Errorf("invalid claim")
 	ErrInvalidClaimOngoing        = fmt.Errorf("claim is still on going")
 	ErrInvalidClaimHasNotFinished = fmt.Errorf("claim hasn't finished")
@@ -23,6 +23,26 @@ var (
 	ErrInvalidClaimProvenAlready  = fmt.Errorf("claim has been proven already")
 	ErrInvalidArr                 = fmt.Errorf("array is either nil or is empty")
 	ErrInvalidGame                = fmt.Errorf("game was not initiated properly")
+	ErrGameOver                   = fmt.Errorf("game is over")
+	ErrInvalidTurn                = fmt.Errorf("it is not the player's turn")
+	ErrInvalidPhase               = fmt.Errorf("not allowed during the current phase")
+	ErrInvalidLossPlayer          = fmt.Errorf("player is not the one losing an influence")
+	ErrInvalidExchange            = fmt.Errorf("cards must be one card per influence out of the exchange pool")
+	ErrAlreadyPassed              = fmt.Errorf("player has already passed")
+	ErrInvalidReactor             = fmt.Errorf("player cannot react right now")
+	ErrInvalidBlocker             = fmt.Errorf("only the target can block this action")
+	ErrInsufficientTreasury       = fmt.Errorf("treasury doesn't have enough coins")
+	ErrInvalidVariant             = fmt.Errorf("the two-player variant needs exactly 2 players")
+	ErrHandSelected               = fmt.Errorf("player has already selected their hand")
+	ErrSameFaction                = fmt.Errorf("players of the same faction cannot target each other")
+	ErrInvalidReformation         = fmt.Errorf("action requires the Reformation expansion")
+	ErrInvalidExaminee            = fmt.Errorf("player is not the one being examined")
+	ErrInvalidExaminer            = fmt.Errorf("player is not the Inquisitor examining")
+	ErrInvalidRules               = fmt.Errorf("game cannot be played with these rules")
+	ErrMandatoryCoup              = fmt.Errorf("player has too many coins and must coup")
+	ErrInvalidClaimNoAction       = fmt.Errorf("character has no action to claim")
+	ErrInvalidClaimNoTarget       = fmt.Errorf("no player can be targeted by the character's action")
+	ErrEmptyReserve               = fmt.Errorf("treasury reserve holds no coins to embezzle")
 )
 
 // Game is a data structure that essentially connects all the loose data
@@ -41,38 +61,130 @@ var (
 //          heavily on an external package to translate client commands
 //          into game actions.
 type Game struct {
-	deck       []uint8
-	deckMtx    sync.Mutex
-	players    [5]*Player
-	turn       *Notifier
-	max        int
-	claim      *claim
-	claimMtx   sync.Mutex
-	action     [2]*Action
-	actionMtx  sync.Mutex
-	history    []Action
+	deck []uint8
+	rand *rand.Rand
+	// source is the random source of rand; nil unless the Game was
+	// created through NewGame. See Snapshot.Draws.
+	source  *countingSource
+	seed    int64
+	seeded  bool
+	deckMtx sync.Mutex
+	players []*Player
+	turn    *Notifier
+	max     int
+	// stack holds the claims and the actions of the current turn; the
+	// primary one at the bottom and its block, if any, on top of it.
+	stack    []*frame
+	loss     *influenceLoss
+	exchange Hand
+	// passed holds the indexes of the players who passed during the
+	// current reaction window.
+	passed     []uint8
+	stackMtx   sync.Mutex
+	phase      uint8
+	phaseStart time.Time
+	timeout    time.Duration
+	phaseMtx   sync.Mutex
+	history    []Entry
+	// turns is the number of the current turn. See Entry.Turn.
+	turns      uint
 	historyMtx sync.Mutex
+	// treasury holds every coin that isn't owned by a player or kept in
+	// reserve, the Treasury Reserve of the Reformation expansion.
+	treasury    uint8
+	reserve     uint8
+	treasuryMtx sync.Mutex
+	// eliminated holds the indexes of dead players in the order they
+	// died. over and winner are only set once one player is left.
+	eliminated    []uint8
+	over          bool
+	winner        uint8
+	eliminatedMtx sync.Mutex
+	// variant is true for the two-player variant. selection holds the set
+	// every seat picks a card out of during PhaseHandSelection, and picks
+	// the picked cards.
+	variant      bool
+	selection    [][]uint8
+	picks        []uint8
+	selectionMtx sync.Mutex
+	// reformation is true for the Reformation expansion.
+	reformation bool
+	// roster holds the characters of the Game's deck in order, and
+	// examination holds the examination of the current turn.
+	roster      []uint8
+	examination *examination
+	// rules holds the amounts the Game is played with. It never changes
+	// once NewGame has returned.
+	rules RuleSet
 }
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+// maxPlayers is the maximum amount of players in a Game.
+const maxPlayers = 10
+
+// copiesFor returns the amount of copies of every character in the deck
+// of a Game with the given amount of players; 3 up to 6 players, 4 up to
+// 8 players and 5 beyond.
+func copiesFor(players int) int {
+	switch {
+	case players <= 6:
+		return 3
+	case players <= 8:
+		return 4
+	}
+
+	return 5
+}
+
+// inPlay returns true if the character is part of the Game's deck. See
+// WithInquisitor and WithCharacters.
+func (g *Game) inPlay(character uint8) bool {
+	for _, v := range g.roster {
+		if v == character {
+			return true
+		}
+	}
+
+	return false
+}
+
+// playable returns the characters that are part of the Game's deck.
+func (g *Game) playable(characters []uint8) []uint8 {
+	arr := []uint8{}
+	for _, character := range characters {
+		if g.inPlay(character) {
+			arr = append(arr, character)
+		}
+	}
+
+	return arr
+}
+
+// characters returns every character of the Game's deck in order.
+func (g *Game) characters() []uint8 {
+	return append([]uint8{}, g.roster...)
 }
 
-var normalDeck = [15]uint8{CardDuke, CardDuke, CardDuke,
-	CardContessa, CardContessa, CardContessa,
-	CardAssassin, CardAssassin, CardAssassin,
-	CardAmbassador, CardAmbassador, CardAmbassador,
-	CardCaptain, CardCaptain, CardCaptain}
+// newDeck returns an unshuffled deck holding copies of every character.
+// See RuleSet.Copies.
+func newDeck(characters []uint8, copies int) []uint8 {
+	deck := []uint8{}
+	for _, character := range characters {
+		for i := 0; i < copies; i++ {
+			deck = append(deck, character)
+		}
+	}
+
+	return deck
+}
 
-// Durstenfeld's version of the fisher-yates algorithm
-func shuffleCards(givenDeck []uint8) []uint8 {
-	// copy the normal deck
-	// deck := append([]uint8{}, normalDeck[:]...)
+// Durstenfeld's version of the fisher-yates algorithm. The deck could be
+// of any size; r is the only source of randomness.
+func shuffleCards(givenDeck []uint8, r *rand.Rand) []uint8 {
 	deck := append([]uint8{}, givenDeck...)
 
 	i := len(givenDeck) - 1
 	for i > 0 {
-		shuffledIndex := rand.Intn(15)
+		shuffledIndex := r.Intn(i + 1)
 
 		deck[shuffledIndex], deck[i] = deck[i], deck[shuffledIndex]
 		i--
@@ -84,37 +196,104 @@ func shuffleCards(givenDeck []uint8) []uint8 {
 // NewGame creates a new game via providing it with a slice of players.
 // The slice of players cannot contain less than 2 nil values, it must
 // have at-least 2 or more.
-func NewGame(pl [5]*Player) (*Game, error) {
-	g := &Game{players: pl}
+//
+// NewGame is a wrapper around NewGameWithPlayers for tables of up to 5
+// seats.
+func NewGame(pl [5]*Player, opts ...Option) (*Game, error) {
+	return NewGameWithPlayers(pl[:], opts...)
+}
+
+// NewGameWithPlayers creates a new game with a seat for every element of
+// pl. Empty seats are nil; there must be 2 to 10 seated players,
+// otherwise ErrInvalidPlayerAmount is returned. Unless RuleSet.Copies is
+// set, the deck grows with the amount of seated players. See copiesFor.
+//
+// The Game is played with DefaultRules unless WithRules is given. If the
+// RuleSet is invalid, or its deck cannot be dealt to every player with
+// enough cards left for an exchange, ErrInvalidRules is returned. If a
+// character given WithCharacters isn't registered, ErrInvalidCharacter is
+// returned.
+//
+// NewGame deals two cards from the shuffled deck and the starting coins
+// from the treasury to every seated player; whatever Hand or Coins they had is
+// overwritten. Every deal is stored in the history as an ActionDeal. The
+// first seated player starts. If the treasury cannot pay the starting
+// coins, ErrInsufficientTreasury is returned.
+//
+// With WithTwoPlayerVariant, NewGame deals the sets of the two-player
+// variant instead and starts in PhaseHandSelection. See Game.SelectHand.
+// Every Faction is overwritten as well. See WithReformation.
+//
+// By default, the deck is shuffled with a random source seeded by the
+// current time. Use WithSeed or WithSource to change that.
+func NewGameWithPlayers(pl []*Player, opts ...Option) (*Game, error) {
+	if len(pl) > maxPlayers {
+		return nil, ErrInvalidPlayerAmount
+	}
 
-	g.deck = shuffleCards(normalDeck[:])
+	g := &Game{players: append([]*Player{}, pl...), roster: classicRoster()}
+	for _, opt := range append(defaultOptions(), opts...) {
+		opt(g)
+	}
 
+	seated, first := 0, -1
 	for k, v := range pl {
-		if v != nil {
-			g.max = k + 1
-		} else {
-			first, second := g.deck[0], g.deck[1]
-			g.deck = g.deck[2:]
-
-			// simple way to tell the history, hey two cards were given..
-			// maybe, someday, this should be its own action instead of piggy
-			// backing off the Ambassador's only good use but that's for
-			// later discussion
-			g.history = append(g.history, Action{
-				Kind:            ActionCharacter,
-				Character:       CardAmbassador,
-				AmbassadorHand:  Hand{first, second},
-				AmbassadorPlace: Hand{0, 1},
-			})
+		if v == nil {
+			continue
 		}
+
+		seated++
+		g.max = k + 1
+		if first < 0 {
+			first = k
+		}
+	}
+
+	coins := seated * int(g.rules.StartingCoins)
+	if g.variant {
+		coins -= int(g.rules.StartingCoins - g.rules.variantCoins())
 	}
 
-	if g.max < 2 {
+	deck := newDeck(g.characters(), g.rules.copies(seated))
+	if seated < 2 {
 		return nil, ErrInvalidPlayerAmount
+	} else if g.variant && seated != 2 {
+		return nil, ErrInvalidVariant
+	} else if err := g.rules.Validate(); err != nil {
+		return nil, err
+	} else if !validRoster(g.roster) {
+		return nil, ErrInvalidCharacter
+	} else if !g.variant && len(deck) < seated*2+2 {
+		return nil, ErrInvalidRules
+	} else if coins > int(^uint8(0)) || !g.withdraw(uint8(coins)) {
+		return nil, ErrInsufficientTreasury
 	}
 
 	g.turn = NewNotifier()
-	g.turn.Set(0)
+	g.turn.Set(first)
+	g.assignFactions(first)
+
+	if g.variant {
+		g.dealSelection(first)
+		return g, nil
+	}
+
+	g.deck = shuffleCards(deck, g.rand)
+
+	for k, v := range pl[:g.max] {
+		if v == nil {
+			continue
+		}
+
+		cards := g.DrawCards(2)
+
+		v.Hand, v.Coins = Hand{cards[0], cards[1]}, g.rules.StartingCoins
+		g.addActionToHistory(Action{
+			AuthorID: uint8(k),
+			Kind:     ActionDeal,
+			Cards:    cards,
+		})
+	}
 
 	return g, nil
 }
@@ -135,6 +314,24 @@ func nextTurn(i int, max int) int {
 	return i + 1
 }
 
+// nextAliveTurn is a function that works like nextTurn but skips every
+// seat that is either empty or occupied by a dead player. If no other
+// seat is alive, i is returned as is.
+//
+// If max = 0; it returns -1.
+func nextAliveTurn(arr []*Player, i int, max int) int {
+	next := nextTurn(i, max)
+	for next >= 0 && next != i {
+		if next < len(arr) && arr[next] != nil && !arr[next].IsDead() {
+			return next
+		}
+
+		next = nextTurn(next, max)
+	}
+
+	return next
+}
+
 // findPlayerByPntr is a function that tries to a locate a player in a
 // slice by comparing pointers. If found, the player's index is returned.
 //
@@ -176,22 +373,65 @@ func validateClaimAndItsPlayer(arr []*Player, c *claim) (int, error) {
 // validateClaimAndItsPlayer is essentially a wrapper over the non-game
 // function validateClaimAndItsPlayer.
 func (g *Game) validateClaimAndItsPlayer(c *claim) (int, error) {
-	return validateClaimAndItsPlayer(g.players[:], c)
+	return validateClaimAndItsPlayer(g.players, c)
+}
+
+// isTurn returns true if index is the index of the player whose turn
+// it currently is.
+func (g *Game) isTurn(index int) bool {
+	turn, err := g.TurnGet()
+
+	return err == nil && turn == index
 }
 
 // addActionToHistory is a function that adds an action to the history
-// slice of the game. It also locks and unlocks the history's mutex so
-// that operations are safe when used asynchronously.
-func (g *Game) addActionToHistory(a Action) {
-	g.historyMtx.Lock()
-	g.history = append(g.history, a)
-	g.historyMtx.Unlock()
+// slice of the game and returns the Seq of its Entry. It also locks and
+// unlocks the history's mutex so that operations are safe when used
+// asynchronously.
+func (g *Game) addActionToHistory(a Action) uint64 {
+	return g.addEntry(a, nil)
 }
 
 // addClaimToHistory is a wrapper around addActionToHistory and
-// Claim.Action.
+// Claim.Action. A claim that has passed responds to the claim itself.
 func (g *Game) addClaimToHistory(c *claim, authorId uint8) {
-	g.addActionToHistory(c.Action(authorId))
+	a := c.Action(authorId)
+	if a.Kind == ActionClaim {
+		c.seq = g.addEntry(a, c.cause)
+		return
+	}
+
+	g.addResponseToHistory(a, c.seq)
+}
+
+// addFrameToHistory is a wrapper around addActionToHistory for the
+// Action of a frame. The Action responds to its declaration, if any, or
+// to the claim backing it. See Game.declare.
+func (g *Game) addFrameToHistory(f *frame) uint64 {
+	switch {
+	case f.seq != nil:
+		return g.addResponseToHistory(*f.action, *f.seq)
+	case f.claim != nil:
+		return g.addResponseToHistory(*f.action, f.claim.seq)
+	}
+
+	return g.addActionToHistory(*f.action)
+}
+
+// declare stores the Action of the frame in the history as soon as it is
+// declared, so that blocks and passes can respond to it. See
+// Action.Declared.
+func (g *Game) declare(f *frame) {
+	a := *f.action
+	a.Declared = true
+
+	var seq uint64
+	if f.claim == nil {
+		seq = g.addActionToHistory(a)
+	} else {
+		seq = g.addResponseToHistory(a, f.claim.seq)
+	}
+	f.seq = &seq
 }
 
 // Claim is a function that sets the current claim for the Game.
@@ -224,16 +464,43 @@ func (g *Game) addClaimToHistory(c *claim, authorId uint8) {
 // them. One could always counter-claim if the original Action is counterable.
 // Like, for example, an Assassin and a Contessa or a Captain and another
 // Captain.
+//
+// A Claim made during PhaseAction is the primary claim of the turn and
+// therefore must come from the player whose turn it is, otherwise
+// ErrInvalidTurn is returned. A player with 10 or more coins cannot
+// Claim; they must Coup. The character's Action must be playable once the
+// Claim holds up; a character without an Action, like the Contessa,
+// returns ErrInvalidClaimNoAction, one the author cannot pay for returns
+// ErrInvalidActionCoins, one the treasury cannot pay returns
+// ErrInsufficientTreasury and one without a player to target returns
+// ErrInvalidClaimNoTarget. See Game.LegalMoves. A Claim made during
+// PhaseBlock is a counter
+// claim; its character must be able to counter the primary Action. See
+// IsValidCounterAction. Only the target of a Captain or an Assassin may
+// block them, otherwise ErrInvalidBlocker is returned. Characters that
+// aren't part of the deck cannot be claimed. See WithInquisitor. With the
+// Reformation expansion, ErrSameFaction is returned if the blocker shares
+// the faction of the primary Action's author.
 func (g *Game) Claim(author *Player, character uint8) error {
-	g.claimMtx.Lock()
-	defer g.claimMtx.Unlock()
-	if g.claim != nil {
+	if g.IsOver() {
+		return ErrGameOver
+	} else if g.Phase() == PhaseHandSelection {
+		return ErrInvalidPhase
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	phase := g.Phase()
+	if phase == PhaseAction && len(g.stack) > 0 {
 		return ErrInvalidClaimOngoing
 	}
 
 	c := &claim{author: author, character: character}
 	if err := c.IsValid(); err != nil {
 		return err
+	} else if !g.inPlay(character) {
+		return ErrInvalidCharacter
 	}
 
 	index, err := g.validateClaimAndItsPlayer(c)
@@ -241,62 +508,148 @@ func (g *Game) Claim(author *Player, character uint8) error {
 		return err
 	}
 
+	f := &frame{claim: c}
+	switch phase {
+	case PhaseAction:
+		if !g.isTurn(index) {
+			return ErrInvalidTurn
+		} else if g.rules.mustCoup(author.Coins) {
+			return ErrMandatoryCoup
+		} else if err := g.claimable(index, character); err != nil {
+			return err
+		}
+
+		phase = PhaseReaction
+	case PhaseBlock:
+		primary := g.top().action
+		counter := Action{AuthorID: uint8(index), author: author, Kind: ActionCharacter, Character: character}
+
+		if primary.author == author {
+			return ErrInvalidActionSamePlayer
+		} else if g.hasPassed(uint8(index)) {
+			return ErrAlreadyPassed
+		} else if !isValidBlocker(*primary, uint8(index)) {
+			return ErrInvalidBlocker
+		} else if !g.mayTarget(uint8(index), primary.AuthorID) {
+			return ErrSameFaction
+		} else if !IsValidCounterAction(*primary, counter) {
+			return ErrInvalidCounterClaim
+		}
+
+		counter.AgainstID, counter.against = newUint8(primary.AuthorID), primary.author
+
+		f.action, f.counter = &counter, true
+		c.cause = g.stack[0].seq
+		phase = PhaseBlockChallenge
+	default:
+		return ErrInvalidPhase
+	}
+
 	g.addClaimToHistory(c, uint8(index))
-	g.claim = c
+	g.push(f)
+	g.openWindow(phase)
 
 	return nil
 }
 
 // ClaimPass makes the underlying claim pass, allowing future character
 // actions to succeed.
+//
+// If the claim was the primary claim, the Game goes back to PhaseAction,
+// or to PhaseExchange for an Ambassador, to wait for the character's
+// Action. If the claim was a counter claim, the block stands and the Game
+// moves to PhaseResolve.
+//
+// Do note: ClaimPass closes the reaction window regardless of who has
+//          passed so far; it is meant for timeouts. Players pass one by
+//          one through Game.Pass.
 func (g *Game) ClaimPass() error {
-	g.claimMtx.Lock()
-	defer g.claimMtx.Unlock()
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
 
-	if g.claim == nil {
+	c := g.currentClaim()
+	if c == nil {
 		return ErrInvalidClaim
-	} else if g.claim.IsFinished() {
+	} else if c.IsFinished() {
 		return ErrInvalidClaimFinished
+	} else if phase := g.Phase(); phase != PhaseReaction && phase != PhaseBlockChallenge {
+		return ErrInvalidPhase
 	}
 
-	index, _ := g.validateClaimAndItsPlayer(g.claim)
-
-	g.claim.Pass()
-	g.addClaimToHistory(g.claim, uint8(index))
+	g.passClaim(c)
 
 	return nil
 }
 
+// passClaim makes the claim pass and resolves it.
+//
+// passClaim must be called while holding stackMtx.
+func (g *Game) passClaim(c *claim) {
+	index, _ := g.validateClaimAndItsPlayer(c)
+
+	c.Pass()
+	g.addClaimToHistory(c, uint8(index))
+
+	g.resolveClaim(true)
+}
+
 // ClaimChallenge challenges the underlying claim. This function will freeze
 // any calls to Action until the Claim has been proven and the appropriate
 // player was punished.
+//
+// An inverted claim is proven right away; there is nothing to choose.
+// See ActionEmbezzle.
 func (g *Game) ClaimChallenge(challenger *Player) error {
-	g.claimMtx.Lock()
-	defer g.claimMtx.Unlock()
+	if g.IsOver() {
+		return ErrGameOver
+	}
 
-	if g.claim == nil {
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	c := g.currentClaim()
+	if c == nil {
 		return ErrInvalidClaim
-	} else if g.claim.IsFinished() {
+	} else if c.IsFinished() {
 		return ErrInvalidClaimFinished
-	} else if g.claim.author == challenger {
+	} else if c.author == challenger {
 		return ErrInvalidActionSamePlayer
 	}
 
-	challengerIndex := findPlayerByPntr(g.players[:], challenger)
-	if challengerIndex < 0 {
+	challengerIndex := findPlayerByPntr(g.players, challenger)
+	if challengerIndex < 0 || challenger.IsDead() {
 		return ErrInvalidPlayer
 	}
 
-	index, _ := g.validateClaimAndItsPlayer(g.claim)
+	if phase := g.Phase(); phase != PhaseReaction && phase != PhaseBlockChallenge {
+		return ErrInvalidPhase
+	} else if g.hasPassed(uint8(challengerIndex)) {
+		return ErrAlreadyPassed
+	}
+
+	index, _ := g.validateClaimAndItsPlayer(c)
 
-	historyItem := g.claim.Action(uint8(index))
+	c.Challenge()
+	c.challenger = challenger
+
+	historyItem := c.Action(uint8(index))
 
 	val := historyItem.AuthorID
 	historyItem.AgainstID, historyItem.against = &val, historyItem.author
 	historyItem.AuthorID, historyItem.author = uint8(challengerIndex), challenger
 
-	g.claim.Challenge()
-	g.addActionToHistory(historyItem)
+	c.challengeSeq = g.addResponseToHistory(historyItem, c.seq)
+
+	if c.inverted {
+		g.proveInverted(c)
+		return nil
+	}
+
+	g.setPhase(PhaseProof)
 
 	return nil
 }
@@ -306,46 +659,213 @@ func (g *Game) ClaimChallenge(challenger *Player) error {
 // the first parameter.
 //
 // If the proof matched the claim; the challenger gets punished; if not;
-// the claimant gets punished.
+// the claimant gets punished. Either way, the punished player must lose
+// an influence. See Game.LoseInfluence.
+//
+// The proof is stored in the history as an ActionClaimProof that
+// responds to the challenge of the claim. See Entry.Cause.
+//
+// The proof must be a card in the claimant's hand, otherwise
+// ErrInvalidCharacter is returned. A proof that matched the claim is
+// shuffled back into the deck and the claimant draws a new card in its
+// place; the new card is stored in the history as an
+// ActionClaimTakeCard that only the claimant can see. See
+// Game.HistoryFor.
 func (g *Game) ClaimProve(character uint8) (bool, error) {
-	g.claimMtx.Lock()
-	defer g.claimMtx.Unlock()
+	if g.IsOver() {
+		return false, ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
 
-	if g.claim == nil {
+	c := g.currentClaim()
+	if c == nil {
 		return false, ErrInvalidClaim
-	} else if !g.claim.IsFinished() {
+	} else if !c.IsFinished() {
 		return false, ErrInvalidClaimHasNotFinished
-	} else if _, challenge := g.claim.Results(); challenge == nil {
+	} else if _, challenge := c.Results(); challenge == nil {
 		return false, ErrInvalidClaimNotChallenged
+	} else if g.Phase() != PhaseProof {
+		return false, ErrInvalidPhase
 	}
 
-	originalCharacter := uint8(0)
+	place, ok := c.author.Hand.find(character)
+	if !ok {
+		return false, ErrInvalidCharacter
+	}
 
-	g.historyMtx.Lock()
+	index, _ := g.validateClaimAndItsPlayer(c)
+	challenger := uint8(findPlayerByPntr(g.players, c.challenger))
 
-	lastAction := g.history[len(g.history)-1]
-	lastAction.Kind = ActionClaimProof
-	originalCharacter = lastAction.Character
-	lastAction.Character = character
-	lastAction.author, lastAction.against = lastAction.against, lastAction.author
-	againstId := lastAction.AuthorID
-	lastAction.AuthorID, lastAction.AgainstID = *lastAction.AgainstID, &againstId
-	g.history = append(g.history, lastAction)
-	g.historyMtx.Unlock()
+	c.proofSeq = g.addResponseToHistory(Action{
+		AuthorID:  uint8(index),
+		author:    c.author,
+		AgainstID: &challenger,
+		against:   c.challenger,
+		Kind:      ActionClaimProof,
+		Character: character,
+		Inverted:  c.inverted,
+	}, c.challengeSeq)
 
-	succeed := originalCharacter == character
-	g.claim.Prove(originalCharacter == character)
+	succeed := c.character == character
+	c.Prove(succeed)
+
+	if succeed {
+		g.takeCards(c.author, []uint8{place}, c.proofSeq)
+	}
+
+	_, loser := c.challengeOutcome()
+
+	g.setLoss(loser, true, c.proofSeq)
+
+	g.updateEliminations()
 
 	return succeed, nil
 }
 
-// Action is a function that sets a Game's underlying Action. Once an action
-// or two have been set, Actions are executed with Game.DoAction.
+// takeCards returns the cards at places to the deck, shuffles the deck
+// and replaces the cards with new ones. Every new card is stored in the
+// history as an ActionClaimTakeCard that responds to the Entry at cause.
+func (g *Game) takeCards(player *Player, places []uint8, cause uint64) {
+	for _, place := range places {
+		g.ReturnCards([]uint8{player.Hand[place]})
+	}
+	g.Shuffle()
+
+	for _, place := range places {
+		place := place
+		cards := g.DrawCards(1)
+		player.Hand[place] = cards[0]
+
+		g.addResponseToHistory(Action{
+			AuthorID:      uint8(findPlayerByPntr(g.players, player)),
+			Kind:          ActionClaimTakeCard,
+			AssassinPlace: &place,
+			Cards:         cards,
+		}, cause)
+	}
+}
+
+// resolveClaim moves the Game to its next phase once the claim on top of
+// the stack has been resolved; either by passing or by a proof. held
+// reports whether the claim held up.
+//
+// A counter claim that didn't hold up is removed from the stack, so that
+// the frame below it takes effect again.
+//
+// resolveClaim must be called while holding stackMtx.
+func (g *Game) resolveClaim(held bool) {
+	f := g.top()
+	if !f.counter {
+		if !held {
+			g.endTurn()
+		} else if f.action != nil {
+			g.setPhase(PhaseResolve)
+		} else if drawsOnClaim(f.claim.character) {
+			g.drawExchange(Action{Kind: ActionCharacter, Character: f.claim.character})
+		} else {
+			g.setPhase(PhaseAction)
+		}
+
+		return
+	}
+
+	if !held {
+		g.pop()
+	}
+
+	g.setPhase(PhaseResolve)
+}
+
+// drawExchange draws the cards that the author of the Action exchanges
+// into the exchange pool and moves the Game to PhaseExchange. The draw is
+// stored in the history as an ActionExchangeDraw, in response to the
+// claim. See Character.Draws.
+//
+// drawExchange must be called while holding stackMtx.
+func (g *Game) drawExchange(a Action) {
+	c := g.currentClaim()
+	index, _ := g.validateClaimAndItsPlayer(c)
+
+	cards := g.DrawCards(draws(a))
+	g.exchange = Hand{}
+	copy(g.exchange[:], cards)
+
+	g.addResponseToHistory(Action{
+		AuthorID:  uint8(index),
+		Kind:      ActionExchangeDraw,
+		Character: c.character,
+		Cards:     cards,
+	}, c.seq)
+	g.setPhase(PhaseExchange)
+}
+
+// endTurn clears the stack of the turn and moves the Game to
+// PhaseTurnEnd. The cards drawn for an exchange that wasn't done, like
+// when the turn times out, are shuffled back into the deck.
+//
+// endTurn must be called while holding stackMtx.
+func (g *Game) endTurn() {
+	if places := g.exchange.places(); len(places) > 0 {
+		for _, place := range places {
+			g.ReturnCards([]uint8{g.exchange[place]})
+		}
+		g.Shuffle()
+	}
+
+	g.stack, g.loss, g.exchange, g.passed = nil, nil, Hand{}, nil
+	g.examination = nil
+	g.setPhase(PhaseTurnEnd)
+}
+
+// Action is a function that sets a Game's underlying Action. Once an
+// action has been set, it is executed with Game.DoAction.
+//
+// During PhaseAction, the Action is the primary action of the turn, so
+// its author must be the player whose turn it is. A player who starts
+// their turn with 10 or more coins must Coup; any other Action returns
+// ErrMandatoryCoup. A character's Action also requires its Claim to have
+// passed first. Actions that could be countered move the Game to
+// PhaseBlock, the rest move it to PhaseResolve. Either way, the
+// declaration is stored in the history right away. See Action.Declared.
+//
+// Actions paid by the treasury fail with ErrInsufficientTreasury if the
+// treasury is short. See Game.Treasury.
 //
-// If two actions have been set, in the same breath, before calling DoAction,
-// then DoAction only executes the last Action.
+// Coups and assassinations must have a target and enough coins to be
+// paid for. Their AssassinPlace must be nil since the target is the one
+// choosing which card to lose. See Game.LoseInfluence. Any other Action
+// with a target it cannot have, or a character's Action the character
+// doesn't have, returns ErrInvalidAction; i.e. a Duke with a target or a
+// Contessa. The Actions accepted are the ones of Game.LegalMoves.
+//
+// ActionConvert and ActionEmbezzle return ErrInvalidReformation outside
+// of the Reformation expansion. With the expansion, targeted Actions
+// other than ActionConvert cannot target a player of the author's
+// faction; ErrSameFaction is returned. See WithReformation.
+// ActionEmbezzle is an inverted claim of the Duke; it moves the Game to
+// PhaseReaction instead. It returns ErrEmptyReserve unless the reserve
+// holds coins. See ActionEmbezzle.
+//
+// During PhaseExchange, only the exchange of the Ambassador or the
+// Inquisitor is accepted. Its Cards must hold the cards the player keeps
+// out of Game.ExchangePool, otherwise ErrInvalidExchange is returned. The
+// drawn cards are set by the Game; AmbassadorHand is overwritten.
+//
+// An Inquisitor without a target draws a single card, stored in the
+// history as an ActionExchangeDraw, and moves the Game to PhaseExchange,
+// where it is exchanged like the Ambassador's. With a target, the
+// Inquisitor examines one of their cards once the Action is done. See
+// Game.ShowCard and Game.Examine.
 func (g *Game) Action(a Action) error {
-	if err := a.setPlayer(g.players[:]); err != nil {
+	if g.IsOver() {
+		return ErrGameOver
+	} else if g.Phase() == PhaseHandSelection {
+		return ErrInvalidPhase
+	}
+
+	if err := a.setPlayer(g.players); err != nil {
 		return err
 	}
 
@@ -353,82 +873,279 @@ func (g *Game) Action(a Action) error {
 		return err
 	}
 
-	g.claimMtx.Lock()
-	c := g.claim
-	g.claimMtx.Unlock()
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	c := g.currentClaim()
+
+	phase := g.Phase()
+	if phase != PhaseAction && phase != PhaseExchange {
+		return ErrInvalidPhase
+	}
+
+	if a.Kind == ActionClaimPunishment {
+		return ErrInvalidActionKind
+	}
 
 	if a.Kind == ActionCharacter && c == nil {
 		return ErrInvalidAction
+	} else if (a.Kind == ActionConvert || a.Kind == ActionEmbezzle) && !g.reformation {
+		return ErrInvalidReformation
+	} else if a.Kind == ActionEmbezzle && g.Reserve() == 0 {
+		return ErrEmptyReserve
 	}
 
 	if c != nil {
 		if err := a.validClaim(c); err != nil {
 			return err
 		}
+	}
 
-		succeed, challenge := c.Results()
-		if succeed != nil && challenge != nil && a.Kind != ActionClaimPunishment {
-			return ErrInvalidActionKind
-		}
+	if isTargeted(a) && a.against == nil {
+		return ErrInvalidActionAgainst
+	} else if !validTarget(a) {
+		return ErrInvalidAction
+	} else if a.against != nil && a.Kind != ActionConvert && !g.mayTarget(a.AuthorID, *a.AgainstID) {
+		return ErrSameFaction
+	} else if a.author.Coins < actionCost(g.rules, a) {
+		return ErrInvalidActionCoins
+	} else if takesInfluence(a) && a.AssassinPlace != nil {
+		return ErrInvalidActionPlaceChoice
+	} else if g.Treasury() < payout(g.rules, a) {
+		return ErrInsufficientTreasury
+	}
+
+	if !g.isTurn(int(a.AuthorID)) {
+		return ErrInvalidTurn
+	} else if g.rules.mustCoup(a.author.Coins) && a.Kind != ActionCoup {
+		return ErrMandatoryCoup
 	}
 
-	g.actionMtx.Lock()
-	defer g.actionMtx.Unlock()
+	if phase == PhaseAction && isExchange(a) {
+		// the Inquisitor draws once its Action is known.
+		g.drawExchange(a)
 
-	if g.action[0] == nil {
-		g.action[0] = &Action{}
-		*g.action[0] = a
-	} else if g.action[1] == nil {
-		if !IsValidCounterAction(*g.action[0], a) {
-			return ErrInvalidCounterClaim
+		return nil
+	}
+
+	if phase == PhaseExchange {
+		if !isExchange(a) {
+			return ErrInvalidExchange
+		} else if _, ok := exchangeRest(a.Cards, a.author.Hand, g.exchange); !ok {
+			return ErrInvalidExchange
 		}
 
-		g.action[1] = &Action{}
-		*g.action[1] = a
+		a.AmbassadorHand = g.exchange
+	}
+
+	if a.Kind == ActionEmbezzle {
+		c := &claim{author: a.author, character: CardDuke, inverted: true}
+
+		g.addClaimToHistory(c, a.AuthorID)
+		g.push(&frame{claim: c, action: &a})
+		g.openWindow(PhaseReaction)
+
+		return nil
+	}
+
+	if c == nil {
+		g.push(&frame{})
+	}
+	g.top().action = &a
+	g.declare(g.top())
+
+	if g.isBlockable(a) {
+		g.openWindow(PhaseBlock)
 	} else {
-		return ErrInvalidAction
+		g.setPhase(PhaseResolve)
 	}
 
 	return nil
 }
 
-// DoAction is a function that executes only the last Action and clears
-// the "stack" of actions.
+// DoAction is a function that resolves the stack of the turn and
+// executes the Action that takes effect. A primary Action that was
+// blocked has no effect, but its cost is still paid; i.e. the coins of a
+// blocked assassination. Only the block is stored in the history, in
+// response to the claim of its author, which responds to the declared
+// Action. See Game.resolveStack.
+//
+// Once a Coup or an assassination has been paid for, its target must lose
+// an influence. See Game.LoseInfluence. Once an Inquisitor has picked a
+// target, the target must show a card. See Game.ShowCard. Otherwise, the
+// Game moves to PhaseTurnEnd.
+//
+// Once the Action has been executed, DoAction looks for newly
+// eliminated players. If only one player remains alive, the game is
+// over and any subsequent call to Claim, Action or DoAction returns
+// ErrGameOver.
 func (g *Game) DoAction() error {
-	g.actionMtx.Lock()
-	defer g.actionMtx.Unlock()
-
-	var act *Action
-	if g.action[1] != nil {
-		act = g.action[1]
-	} else if g.action[0] != nil {
-		act = g.action[0]
-	} else {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	if phase := g.Phase(); phase != PhaseBlock && phase != PhaseResolve {
+		return ErrInvalidPhase
+	}
+
+	blocks, primary := g.resolveStack()
+	if primary == nil && len(blocks) == 0 {
 		return ErrInvalidAction
 	}
 
-	g.historyMtx.Lock()
-	g.history = append(g.history, *act)
-	g.historyMtx.Unlock()
+	for _, v := range blocks {
+		g.addFrameToHistory(v)
+	}
 
-	act.do()
+	if primary == nil {
+		if blocked := g.primaryAction(); blocked != nil {
+			g.forfeit(*blocked)
+		}
+
+		g.endTurn()
+		g.updateEliminations()
+
+		return nil
+	}
+
+	act, seq := primary.action, g.addFrameToHistory(primary)
+	g.settle(*act)
+	act.do(g.rules)
 
 	// An Ambassador *takes* cards away. So, we must return the cards back
 	// once they've finished.
-	if act.Kind == ActionCharacter && act.Character == CardAmbassador {
-		g.deckMtx.Lock()
-		g.deck = append(g.deck, act.AmbassadorHand[:]...)
-		g.deckMtx.Unlock()
+	if isExchange(*act) {
+		for _, place := range act.AmbassadorHand.places() {
+			g.ReturnCards([]uint8{act.AmbassadorHand[place]})
+		}
+		g.Shuffle()
+		g.exchange = Hand{}
+	}
+
+	if takesInfluence(*act) && act.AssassinPlace == nil {
+		g.setLoss(act.against, false, seq)
+	} else if isExamination(*act) {
+		g.setExamination(*act, seq)
+	} else {
+		g.endTurn()
 	}
 
-	g.action[0], g.action[1] = nil, nil
+	g.updateEliminations()
 
 	return nil
 }
 
-// NextTurn changes the turn and announce it.
+// updateEliminations is a function that records every player who died
+// since the last call, in the order they died. If only one player is left
+// alive, the game is marked as over and an ActionGameOver is added to the
+// history.
+func (g *Game) updateEliminations() {
+	g.eliminatedMtx.Lock()
+	defer g.eliminatedMtx.Unlock()
+
+	if g.over {
+		return
+	}
+
+	alive, last := 0, uint8(0)
+	for k, v := range g.players[:g.max] {
+		if v == nil {
+			continue
+		}
+
+		if !v.IsDead() {
+			alive++
+			last = uint8(k)
+			continue
+		}
+
+		recorded := false
+		for _, index := range g.eliminated {
+			if index == uint8(k) {
+				recorded = true
+				break
+			}
+		}
+
+		if !recorded {
+			g.eliminated = append(g.eliminated, uint8(k))
+		}
+	}
+
+	if alive == 1 {
+		g.over, g.winner = true, last
+		g.addActionToHistory(Action{Kind: ActionGameOver, AuthorID: last})
+		g.setPhase(PhaseGameOver)
+	}
+}
+
+// IsOver returns true if only one player is left alive.
+func (g *Game) IsOver() bool {
+	g.eliminatedMtx.Lock()
+	defer g.eliminatedMtx.Unlock()
+
+	return g.over
+}
+
+// Winner returns the index of the last player standing. If the game is
+// not over yet, Winner returns -1.
+func (g *Game) Winner() int {
+	g.eliminatedMtx.Lock()
+	defer g.eliminatedMtx.Unlock()
+
+	if !g.over {
+		return -1
+	}
+
+	return int(g.winner)
+}
+
+// Placement returns the indexes of the players ordered from first place
+// to last place. The winner comes first, followed by the eliminated
+// players in reverse order of elimination.
+//
+// If the game is not over yet, Placement returns nil.
+func (g *Game) Placement() []uint8 {
+	g.eliminatedMtx.Lock()
+	defer g.eliminatedMtx.Unlock()
+
+	if !g.over {
+		return nil
+	}
+
+	arr := []uint8{g.winner}
+	for i := len(g.eliminated) - 1; i >= 0; i-- {
+		arr = append(arr, g.eliminated[i])
+	}
+
+	return arr
+}
+
+// NextTurn changes the turn to the next living player and announce it.
+// Dead players and empty seats are skipped.
+//
+// NextTurn also clears whatever is left of the current turn and moves
+// the Game back to PhaseAction. It does nothing during
+// PhaseHandSelection or once the game is over.
 func (g *Game) NextTurn() {
-	g.turn.Set(nextTurn(g.turn.Get().(int), g.max))
+	if g.Phase() == PhaseHandSelection || g.IsOver() {
+		return
+	}
+
+	g.stackMtx.Lock()
+	g.endTurn()
+	g.stackMtx.Unlock()
+
+	g.historyMtx.Lock()
+	g.turns++
+	g.historyMtx.Unlock()
+
+	g.setPhase(PhaseAction)
+
+	g.turn.Set(nextAliveTurn(g.players, g.turn.Get().(int), g.max))
 	g.turn.Announce()
 }
 
@@ -476,7 +1193,7 @@ func (g *Game) TurnUnsubscribe(val time.Time) (err error) {
 // Shuffle shuffles the Game's deck.
 func (g *Game) Shuffle() {
 	g.deckMtx.Lock()
-	g.deck = shuffleCards(g.deck)
+	g.deck = shuffleCards(g.deck, g.rand)
 	g.deckMtx.Unlock()
 }
 
@@ -506,3 +1223,54 @@ func (g *Game) ReturnCards(arr []uint8) error {
 
 	return nil
 }
+
+// ExchangePool returns the cards the player at index could keep during
+// PhaseExchange; their live cards followed by the cards drawn from the
+// deck. An Ambassador draws two cards and an Inquisitor one.
+//
+// ExchangePool returns nil if the Game isn't in PhaseExchange or if the
+// player at index isn't the Ambassador or the Inquisitor.
+func (g *Game) ExchangePool(index int) []uint8 {
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	return g.exchangePool(index)
+}
+
+// exchangePool is the underlying function of Game.ExchangePool.
+//
+// exchangePool must be called while holding stackMtx.
+func (g *Game) exchangePool(index int) []uint8 {
+	c := g.currentClaim()
+	if g.Phase() != PhaseExchange || c == nil {
+		return nil
+	} else if claimant, err := g.validateClaimAndItsPlayer(c); err != nil || claimant != index {
+		return nil
+	}
+
+	hand := c.author.Hand
+
+	arr := []uint8{}
+	for _, place := range hand.places() {
+		arr = append(arr, hand[place])
+	}
+	for _, place := range g.exchange.places() {
+		arr = append(arr, g.exchange[place])
+	}
+
+	return arr
+}
+
+// Graveyard returns the characters of every revealed card in the Game,
+// ordered by the seat of their player. Revealed cards are public; see
+// Hand.IsRevealed.
+func (g *Game) Graveyard() []uint8 {
+	arr := []uint8{}
+	for _, v := range g.players[:g.max] {
+		if v != nil {
+			arr = append(arr, v.Hand.Revealed()...)
+		}
+	}
+
+	return arr
+}
@@ -1,12 +1,42 @@
 package game
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/matryer/is"
 )
 
+// normalDeck is the deck of DefaultRules for up to 6 players.
+var normalDeck = [15]uint8{CardDuke, CardDuke, CardDuke,
+	CardContessa, CardContessa, CardContessa,
+	CardAssassin, CardAssassin, CardAssassin,
+	CardAmbassador, CardAmbassador, CardAmbassador,
+	CardCaptain, CardCaptain, CardCaptain}
+
+// newTestGame creates a Game through NewGame and replaces the dealt
+// hands with hands, so that tests don't depend on the shuffle. Every
+// player is left without coins; their coins go back to the treasury.
+func newTestGame(t *testing.T, hands ...Hand) *Game {
+	pl := [5]*Player{}
+	for k := range hands {
+		pl[k] = &Player{}
+	}
+
+	g, err := NewGame(pl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range hands {
+		g.treasury += g.players[k].Coins
+		g.players[k].Hand, g.players[k].Coins = v, 0
+	}
+
+	return g
+}
+
 func TestFindPlayerByPntr(t *testing.T) {
 	is := is.New(t)
 
@@ -25,6 +55,19 @@ func TestNextTurn(t *testing.T) {
 	is.Equal(nextTurn(2, 3), 0)
 }
 
+func TestNextAliveTurn(t *testing.T) {
+	is := is.New(t)
+
+	alive, dead := &Player{Hand: Hand{0: CardDuke}}, &Player{}
+	pl := []*Player{alive, dead, nil, alive}
+
+	is.Equal(nextAliveTurn(pl, 0, 0), -1)
+	is.Equal(nextAliveTurn(pl, 0, 4), 3)
+	is.Equal(nextAliveTurn(pl, 3, 4), 0)
+	is.Equal(nextAliveTurn(pl, 0, 3), 0)
+	is.Equal(nextAliveTurn([]*Player{alive, alive}, 0, 2), 1)
+}
+
 func TestNewGame(t *testing.T) {
 	is := is.New(t)
 
@@ -32,57 +75,194 @@ func TestNewGame(t *testing.T) {
 	_, err := NewGame([5]*Player{p})
 	is.Equal(err, ErrInvalidPlayerAmount)
 
+	_, err = NewGame([5]*Player{nil, p})
+	is.Equal(err, ErrInvalidPlayerAmount)
+
 	g, err := NewGame([5]*Player{p, p})
 	is.NoErr(err)
 	is.Equal(g.max, 2)
+
+	pl := [5]*Player{nil, {}, nil, {Coins: 5}}
+	g, err = NewGame(pl)
+	is.NoErr(err)
+	is.Equal(g.max, 4)
+	is.Equal(len(g.deck), 11)
+
+	turn, err := g.TurnGet()
+	is.NoErr(err)
+	is.Equal(turn, 1)
+
+	is.Equal(len(g.history), 2)
+	for k, index := range []uint8{1, 3} {
+		v := g.history[k]
+		is.Equal(v.Kind, ActionDeal)
+		is.Equal(v.AuthorID, index)
+		is.Equal(v.Cards, pl[index].Hand[:])
+		is.True(IsValidCard(pl[index].Hand[0]) && IsValidCard(pl[index].Hand[1]))
+		is.Equal(pl[index].Coins, DefaultRules().StartingCoins)
+	}
+
+	// every card is either in the deck or in a hand
+	count := map[uint8]int{}
+	for _, v := range append(append([]uint8{}, g.deck...), pl[1].Hand[0], pl[1].Hand[1], pl[3].Hand[0], pl[3].Hand[1]) {
+		count[v]++
+	}
+
+	for _, v := range normalDeck {
+		is.Equal(count[v], 3)
+	}
+}
+
+func TestNewGameWithPlayers(t *testing.T) {
+	is := is.New(t)
+
+	_, err := NewGameWithPlayers(make([]*Player, maxPlayers+1))
+	is.Equal(err, ErrInvalidPlayerAmount)
+	_, err = NewGameWithPlayers([]*Player{{}})
+	is.Equal(err, ErrInvalidPlayerAmount)
+
+	for _, seated := range []int{2, 6, 7, 10} {
+		pl := make([]*Player, seated)
+		for k := range pl {
+			pl[k] = &Player{}
+		}
+
+		g, err := NewGameWithPlayers(pl)
+		is.NoErr(err)
+		is.Equal(g.max, seated)
+		is.Equal(len(g.deck), copiesFor(seated)*5-seated*2)
+		is.True(!g.players[seated-1].IsDead())
+	}
+
+	// the Game keeps its own slice of seats
+	pl := []*Player{{}, nil, {}}
+	g, err := NewGameWithPlayers(pl)
+	is.NoErr(err)
+	pl[1] = &Player{}
+	is.Equal(g.players[1], nil)
+	is.Equal(g.max, 3)
+}
+
+func TestCopiesFor(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(copiesFor(2), 3)
+	is.Equal(copiesFor(6), 3)
+	is.Equal(copiesFor(7), 4)
+	is.Equal(copiesFor(8), 4)
+	is.Equal(copiesFor(9), 5)
+	is.Equal(copiesFor(10), 5)
+}
+
+func TestNewDeck(t *testing.T) {
+	is := is.New(t)
+
+	characters := classicRoster()
+	deck := newDeck(characters, 3)
+	is.Equal(len(deck), len(normalDeck))
+
+	count := map[uint8]int{}
+	for _, v := range newDeck(characters, 4) {
+		count[v]++
+	}
+
+	is.Equal(len(count), 5)
+	for character := CardAssassin; character <= CardContessa; character++ {
+		is.Equal(count[character], 4)
+	}
+}
+
+func TestGameInPlay(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{roster: classicRoster()}
+	is.True(g.inPlay(CardAmbassador))
+	is.True(!g.inPlay(CardInquisitor))
+	is.True(!g.inPlay(CardEmpty))
+
+	WithInquisitor()(g)
+	is.True(!g.inPlay(CardAmbassador))
+	is.True(g.inPlay(CardInquisitor))
+	is.True(g.inPlay(CardDuke))
+}
+
+func TestGamePlayable(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{roster: classicRoster()}
+	WithInquisitor()(g)
+	is.Equal(g.playable([]uint8{CardAmbassador, CardCaptain, CardInquisitor}), []uint8{CardCaptain, CardInquisitor})
+	is.Equal(g.playable(nil), []uint8{})
+}
+
+func TestGameCharacters(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{roster: classicRoster()}
+	is.Equal(g.characters(), []uint8{CardAssassin, CardDuke, CardAmbassador, CardCaptain, CardContessa})
+
+	WithInquisitor()(g)
+	is.Equal(g.characters(), []uint8{CardAssassin, CardDuke, CardCaptain, CardContessa, CardInquisitor})
 }
 
 func TestGameAction(t *testing.T) {
-	g, err := NewGame([5]*Player{{Hand: Hand{CardAmbassador, CardAssassin}}, {Hand: Hand{CardDuke, CardContessa}}})
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
 
 	is := is.New(t)
-	is.NoErr(err)
 
 	a1, a2 := Action{}, Action{}
 	a1.AuthorID = 255
 
-	is.Equal(g.Action(a1), a1.setPlayer(g.players[:]))
+	is.Equal(g.Action(a1), a1.setPlayer(g.players))
 
 	a1.AuthorID = 0
-	is.NoErr(a1.setPlayer(g.players[:]))
+	is.NoErr(a1.setPlayer(g.players))
 	is.Equal(g.Action(a1), a1.IsValid())
 
 	a1.Kind = ActionCharacter
 	is.Equal(g.Action(a1), ErrInvalidAction)
-	g.claim = &claim{}
+	is.Equal(g.Action(Action{AuthorID: 1, Kind: ActionIncome}), ErrInvalidTurn)
+	g.stack = []*frame{{claim: &claim{}}}
 
-	is.Equal(g.Action(a1), a1.validClaim(g.claim))
+	is.Equal(g.Action(a1), a1.validClaim(g.currentClaim()))
 
 	a1.Kind = ActionCharacter
-	g.claim = &claim{character: CardContessa}
-	g.claim.succeed, g.claim.challenge = new(bool), new(bool)
+	g.stack = []*frame{{claim: &claim{character: CardContessa}}}
+	g.currentClaim().succeed, g.currentClaim().challenge = new(bool), new(bool)
 	a1.Character = CardContessa
+	g.phase = PhaseInfluenceLoss
 
+	is.Equal(g.Action(a1), ErrInvalidPhase)
+
+	g.phase = PhaseAction
+	a1.Kind = ActionClaimPunishment
 	is.Equal(g.Action(a1), ErrInvalidActionKind)
 
-	g.claim = nil
+	g.stack = nil
+	g.phase = PhaseAction
 
 	a1.Kind = ActionFinancialAid
 	is.NoErr(g.Action(a1))
+	is.Equal(g.Phase(), PhaseBlock)
+
+	is.Equal(g.Action(Action{AuthorID: 1, Kind: ActionIncome}), ErrInvalidPhase)
 
-	is.Equal(g.Action(Action{AuthorID: 1, Kind: ActionIncome}), ErrInvalidCounterClaim)
+	g.phase = PhaseAction
+	g.stack = []*frame{{claim: &claim{}}}
+	g.currentClaim().succeed = new(bool)
+	*g.currentClaim().succeed = true
+	g.currentClaim().character = CardDuke
 
-	g.claim = &claim{}
-	g.claim.succeed = new(bool)
-	*g.claim.succeed = true
-	g.claim.character = CardDuke
+	// the Duke's claim doesn't allow another Action
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionFinancialAid, Character: CardDuke}), ErrInvalidAction)
+	is.Equal(g.Phase(), PhaseAction)
 
 	a2.Kind = ActionCharacter
-	a2.Character = g.claim.character
+	a2.Character = g.currentClaim().character
 
 	is.NoErr(g.Action(a2))
-	is.Equal(g.Action(a2), ErrInvalidAction)
-
+	is.Equal(g.Phase(), PhaseResolve)
+	is.Equal(g.Action(a2), ErrInvalidPhase)
 }
 
 func TestGameNextTurn(t *testing.T) {
@@ -92,7 +272,8 @@ func TestGameNextTurn(t *testing.T) {
 	_, _, err := g.TurnSubscribe()
 	is.Equal(err, ErrInvalidGame)
 
-	g.max = 2
+	g.max = 3
+	g.players = []*Player{{Hand: Hand{0: CardDuke}}, {Hand: Hand{0: CardDuke}}, {}}
 	g.turn = NewNotifier()
 	g.turn.Set(0)
 
@@ -113,6 +294,11 @@ func TestGameNextTurn(t *testing.T) {
 	case <-time.After(time.Millisecond):
 		t.Fatalf("NextTurn doesn't notify subscribers")
 	}
+
+	go func() { <-ch }()
+	g.NextTurn()
+
+	is.Equal(g.turn.Get().(int), 0)
 }
 
 func TestGameTurnGet(t *testing.T) {
@@ -170,130 +356,202 @@ func TestValidateClaimAndItsPlayer(t *testing.T) {
 }
 
 func TestGameClaim(t *testing.T) {
-	g, err := NewGame([5]*Player{{Hand: Hand{CardAmbassador, CardAssassin}}, {Hand: Hand{CardDuke, CardContessa}}})
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
 	is := is.New(t)
-	is.NoErr(err)
 
-	g.claim = &claim{}
+	g.stack = []*frame{{claim: &claim{}}}
 	is.Equal(g.Claim(nil, 0), ErrInvalidClaimOngoing)
 
-	g.claim = nil
+	g.stack = nil
 	is.Equal(g.Claim(nil, CardContessa), (&claim{}).IsValid())
 	is.Equal(g.Claim(&Player{Hand: Hand{0: CardContessa}}, CardContessa), ErrInvalidPlayer)
+	is.Equal(g.Claim(g.players[1], CardDuke), ErrInvalidTurn)
 
 	is.Equal(g.Claim(g.players[0], CardAmbassador), nil)
-	is.True(g.claim != nil)
-	is.Equal(g.history[len(g.history)-1], g.claim.Action(0))
+	is.True(g.currentClaim() != nil)
+	is.Equal(g.history[len(g.history)-1].Action, g.currentClaim().Action(0))
+}
+
+func TestGameClaimUnplayable(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
+	is := is.New(t)
+
+	// the Contessa has no Action to claim
+	is.Equal(g.Claim(g.players[0], CardContessa), ErrInvalidClaimNoAction)
+
+	g.players[0].Coins = g.rules.AssassinCost - 1
+	is.Equal(g.Claim(g.players[0], CardAssassin), ErrInvalidActionCoins)
+
+	g.treasury = g.rules.Tax - 1
+	is.Equal(g.Claim(g.players[0], CardDuke), ErrInsufficientTreasury)
+
+	// nobody is left to target
+	g.players[0].Coins = g.rules.AssassinCost
+	g.players[1].Hand = Hand{CardDuke | cardRevealed, CardContessa | cardRevealed}
+	is.Equal(g.Claim(g.players[0], CardAssassin), ErrInvalidClaimNoTarget)
+	is.Equal(g.Claim(g.players[0], CardCaptain), ErrInvalidClaimNoTarget)
+
+	// none of the claims were recorded
+	is.Equal(g.Phase(), PhaseAction)
+	is.Equal(len(g.stack), 0)
+	for _, v := range g.history {
+		is.True(v.Kind != ActionClaim)
+	}
 }
 
 func TestGameClaimPass(t *testing.T) {
-	g, err := NewGame([5]*Player{{Hand: Hand{CardAmbassador, CardAssassin}}, {Hand: Hand{CardDuke, CardContessa}}})
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
 
 	is := is.New(t)
-	is.NoErr(err)
 
 	is.Equal(g.ClaimPass(), ErrInvalidClaim)
-	g.claim = &claim{succeed: new(bool)}
+	g.stack = []*frame{{claim: &claim{succeed: new(bool)}}}
 
 	is.Equal(g.ClaimPass(), ErrInvalidClaimFinished)
 
-	g.claim.succeed = nil
-	g.claim.author = g.players[0]
+	g.currentClaim().succeed = nil
+	g.currentClaim().author = g.players[0]
+
+	is.Equal(g.ClaimPass(), ErrInvalidPhase)
+	g.phase = PhaseReaction
 
 	is.NoErr(g.ClaimPass())
-	is.Equal(g.history[len(g.history)-1], g.claim.Action(0))
-	is.True(g.claim.succeed != nil)
+	is.Equal(g.history[len(g.history)-1].Action, g.currentClaim().Action(0))
+	is.True(g.currentClaim().succeed != nil)
+	is.Equal(g.Phase(), PhaseAction)
 }
 
 func TestGameClaimChallenge(t *testing.T) {
-	g, err := NewGame([5]*Player{{Hand: Hand{CardAmbassador, CardAssassin}}, {Hand: Hand{CardDuke, CardContessa}}})
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
 
 	is := is.New(t)
-	is.NoErr(err)
 
 	is.Equal(g.ClaimChallenge(g.players[1]), ErrInvalidClaim)
-	g.claim = &claim{succeed: new(bool)}
+	g.stack = []*frame{{claim: &claim{succeed: new(bool)}}}
 
 	is.Equal(g.ClaimChallenge(g.players[1]), ErrInvalidClaimFinished)
 
-	g.claim.succeed = nil
-	g.claim.author = g.players[1]
+	g.currentClaim().succeed = nil
+	g.currentClaim().author = g.players[1]
 
 	is.Equal(g.ClaimChallenge(g.players[1]), ErrInvalidActionSamePlayer)
 
-	g.claim.author = g.players[0]
+	g.currentClaim().author = g.players[0]
 	is.Equal(g.ClaimChallenge(&Player{}), ErrInvalidPlayer)
+	is.Equal(g.ClaimChallenge(g.players[1]), ErrInvalidPhase)
 
+	g.phase = PhaseReaction
 	is.NoErr(g.ClaimChallenge(g.players[1]))
+	is.Equal(g.Phase(), PhaseProof)
+	is.Equal(g.currentClaim().challenger, g.players[1])
 
+	// the challenge is recorded as such, not as another claim
+	is.Equal(g.history[len(g.history)-1].Kind, ActionClaimChallenge)
 	is.Equal(g.history[len(g.history)-1].AuthorID, uint8(1))
 	is.Equal(*g.history[len(g.history)-1].AgainstID, uint8(0))
-	is.True(g.claim.challenge != nil)
+	is.True(g.currentClaim().challenge != nil)
 }
 
 func TestGameClaimProve(t *testing.T) {
-	g, err := NewGame([5]*Player{{Hand: Hand{CardAmbassador, CardAssassin}}, {Hand: Hand{CardDuke, CardContessa}}})
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
 
 	is := is.New(t)
-	is.NoErr(err)
 
-	_, err = g.ClaimProve(CardContessa)
+	_, err := g.ClaimProve(CardContessa)
 	is.Equal(err, ErrInvalidClaim)
-	g.claim = &claim{}
+	g.stack = []*frame{{claim: &claim{}}}
 
 	_, err = g.ClaimProve(CardContessa)
 	is.Equal(err, ErrInvalidClaimHasNotFinished)
 
-	g.claim.succeed = new(bool)
+	g.currentClaim().succeed = new(bool)
 	_, err = g.ClaimProve(CardContessa)
 	is.Equal(err, ErrInvalidClaimNotChallenged)
 
-	g.claim = nil
+	g.stack = nil
 
-	is.NoErr(g.Claim(g.players[0], CardContessa))
+	g.players[0].Coins = g.rules.AssassinCost
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
 	is.NoErr(g.ClaimChallenge(g.players[1]))
 
-	result, err := g.ClaimProve(CardContessa)
+	// the proof must be in the claimant's hand
+	_, err = g.ClaimProve(CardContessa)
+	is.Equal(err, ErrInvalidCharacter)
+
+	deck := len(g.deck)
+	result, err := g.ClaimProve(CardAssassin)
 
 	is.NoErr(err)
 	is.True(result)
 
-	is.Equal(g.history[len(g.history)-1].AuthorID, uint8(0))
-	is.Equal(*g.history[len(g.history)-1].AgainstID, uint8(1))
+	proof := g.history[len(g.history)-2]
+	is.Equal(proof.Kind, ActionClaimProof)
+	is.Equal(proof.AuthorID, uint8(0))
+	is.Equal(*proof.AgainstID, uint8(1))
+
+	// the proven card is swapped for a new one
+	take := g.history[len(g.history)-1]
+	is.Equal(take.Kind, ActionClaimTakeCard)
+	is.Equal(take.AuthorID, uint8(0))
+	is.Equal(*take.AssassinPlace, uint8(1))
+	is.Equal(take.Cards, []uint8{g.players[0].Hand[1]})
+	is.Equal(g.players[0].Hand[0], CardAmbassador)
+	is.Equal(len(g.deck), deck)
+
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+
+	_, err = g.ClaimProve(CardAssassin)
+	is.Equal(err, ErrInvalidPhase)
 
-	g.history = g.history[:len(g.history)-1]
-	g.claim.succeed = nil
+	g.history = g.history[:len(g.history)-2]
+	g.currentClaim().succeed = nil
+	g.phase = PhaseProof
 
-	result, err = g.ClaimProve(CardAssassin)
+	hand := g.players[0].Hand
+	result, err = g.ClaimProve(CardAmbassador)
 	is.NoErr(err)
 	is.True(!result)
+	is.Equal(g.players[0].Hand, hand)
+	is.Equal(g.history[len(g.history)-1].Kind, ActionClaimProof)
+	is.Equal(g.loss.victim, g.players[0])
 }
 
 func TestGameDoAction(t *testing.T) {
 
 	is := is.New(t)
 
-	g := &Game{}
+	g := &Game{rules: DefaultRules()}
 
 	pl1, pl2 := &Player{}, &Player{}
 
-	g.action[1] = &Action{author: pl2, Kind: ActionIncome}
-	g.action[0] = &Action{author: pl1, Kind: ActionIncome}
+	block := Action{author: pl2, Kind: ActionCharacter, Character: CardDuke}
+	g.stack = []*frame{
+		{action: &Action{author: pl1, Kind: ActionFinancialAid}},
+		{action: &block, counter: true},
+	}
+
+	is.Equal(g.DoAction(), ErrInvalidPhase)
+	g.phase = PhaseResolve
 
+	// the block cancels the primary action and has no effect by itself
 	is.NoErr(g.DoAction())
+	is.Equal(g.Phase(), PhaseTurnEnd)
 
 	is.Equal(pl1.Coins, uint8(0))
-	is.Equal(pl2.Coins, uint8(1))
-	is.Equal(g.action[0], nil)
-	is.Equal(g.action[1], nil)
+	is.Equal(pl2.Coins, uint8(0))
+	is.Equal(g.history[len(g.history)-1].Action, block)
+	is.Equal(g.stack, nil)
 
-	g.action[0] = &Action{author: pl1, Kind: ActionIncome}
+	g.stack = []*frame{{action: &Action{author: pl1, Kind: ActionIncome}}}
+	g.phase = PhaseResolve
 	is.NoErr(g.DoAction())
 	is.Equal(pl1.Coins, uint8(1))
 
+	g.phase = PhaseResolve
 	is.Equal(g.DoAction(), ErrInvalidAction)
 
-	g.action[0] = &Action{author: pl1, Kind: ActionCharacter, Character: CardAmbassador, AmbassadorHand: Hand{CardContessa, CardContessa}}
+	g.rand = rand.New(rand.NewSource(0))
+	g.stack = []*frame{{action: &Action{author: pl1, Kind: ActionCharacter, Character: CardAmbassador, AmbassadorHand: Hand{CardContessa, CardContessa}}}}
 
 	is.NoErr(g.DoAction())
 	is.Equal(len(g.deck), 2)
@@ -304,15 +562,28 @@ func TestGenerateDeck(t *testing.T) {
 	is := is.New(t)
 
 	want, have := [15]uint8{}, [15]uint8{}
-	is.Equal(copy(want[:], shuffleCards(normalDeck[:])), 15)
-	is.Equal(copy(have[:], shuffleCards(normalDeck[:])), 15)
+	is.Equal(copy(want[:], shuffleCards(normalDeck[:], rand.New(rand.NewSource(1)))), 15)
+	is.Equal(copy(have[:], shuffleCards(normalDeck[:], rand.New(rand.NewSource(2)))), 15)
 
 	is.True(want != have)
+
+	is.Equal(copy(have[:], shuffleCards(normalDeck[:], rand.New(rand.NewSource(1)))), 15)
+	is.Equal(want, have)
+
+	// shorter decks must not go out of range
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		short := shuffleCards(normalDeck[:4], r)
+		is.Equal(len(short), 4)
+		is.Equal(short[0]+short[1]+short[2]+short[3], CardDuke*3+CardContessa)
+	}
+
+	is.Equal(shuffleCards([]uint8{}, r), []uint8{})
 }
 
 func TestGameShuffle(t *testing.T) {
-	g := &Game{}
-	g.deck = shuffleCards(normalDeck[:])
+	g := &Game{rand: rand.New(rand.NewSource(1))}
+	g.deck = shuffleCards(normalDeck[:], g.rand)
 
 	is := is.New(t)
 
@@ -328,12 +599,12 @@ func TestGameShuffle(t *testing.T) {
 
 func TestGameDrawCards(t *testing.T) {
 	g := &Game{}
-	g.deck = shuffleCards(normalDeck[:])
+	g.deck = shuffleCards(normalDeck[:], rand.New(rand.NewSource(1)))
 
 	is := is.New(t)
 
 	last := g.deck[:2]
-	draw := g.DrawCards(2)
+	drawn := g.DrawCards(2)
 	is.Equal(len(drawn), 2)
 	is.Equal(drawn, last)
 	is.Equal(len(g.deck), 13)
@@ -351,3 +622,213 @@ func TestGameReturnCards(t *testing.T) {
 	is.NoErr(g.ReturnCards(arr))
 	is.Equal(g.deck, arr)
 }
+
+func TestGameIsOver(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardEmpty})
+
+	is := is.New(t)
+
+	is.True(!g.IsOver())
+	is.Equal(g.Winner(), -1)
+	is.Equal(g.Placement(), nil)
+
+	g.players[0].Coins = 7
+	against := uint8(1)
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: &against}))
+	is.NoErr(g.DoAction())
+
+	is.True(g.IsOver())
+	is.Equal(g.Winner(), 0)
+	is.Equal(g.Placement(), []uint8{0, 1})
+	is.Equal(g.history[len(g.history)-1].Action, Action{Kind: ActionGameOver, AuthorID: 0})
+
+	is.Equal(g.Claim(g.players[0], CardDuke), ErrGameOver)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionIncome}), ErrGameOver)
+	is.Equal(g.DoAction(), ErrGameOver)
+
+	// the game stays over
+	turn, _ := g.TurnGet()
+	g.NextTurn()
+	is.Equal(g.Phase(), PhaseGameOver)
+	is.Equal(g.turnNumber(), uint(0))
+	is.Equal(g.turn.Get().(int), turn)
+
+	// must not be recorded twice
+	g.updateEliminations()
+	is.Equal(g.history[len(g.history)-1].Kind, ActionGameOver)
+	is.Equal(g.history[len(g.history)-2].Kind, ActionInfluenceLoss)
+	is.Equal(g.history[len(g.history)-3].Kind, ActionCoup)
+}
+
+func TestGameUpdateEliminations(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador}, Hand{CardDuke}, Hand{CardContessa})
+
+	is := is.New(t)
+
+	g.players[1].Hand = Hand{}
+	g.updateEliminations()
+	is.Equal(g.eliminated, []uint8{1})
+	is.True(!g.IsOver())
+
+	g.players[0].Hand = Hand{}
+	g.updateEliminations()
+	is.Equal(g.eliminated, []uint8{1, 0})
+	is.True(g.IsOver())
+	is.Equal(g.Winner(), 2)
+	is.Equal(g.Placement(), []uint8{2, 0, 1})
+}
+
+func TestGameMandatoryCoup(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	g.players[0].Coins = 10
+
+	is.Equal(g.Claim(g.players[0], CardDuke), ErrMandatoryCoup)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionIncome}), ErrMandatoryCoup)
+
+	against := uint8(1)
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: &against}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Coins, uint8(3))
+	is.NoErr(g.LoseInfluence(g.players[1], 0))
+
+	g.NextTurn()
+
+	// coins are not capped at 10 anymore
+	g.players[1].Coins = 9
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionFinancialAid}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[1].Coins, uint8(11))
+}
+
+func TestGameGraveyard(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardCaptain, CardDuke})
+
+	is := is.New(t)
+	is.Equal(g.Graveyard(), []uint8{})
+
+	g.players[2].Hand = removeFromHand(1, g.players[2].Hand)
+	g.players[0].Hand = removeFromHand(0, g.players[0].Hand)
+	is.Equal(g.Graveyard(), []uint8{CardAmbassador, CardDuke})
+
+	g.players[2].Hand = removeFromHand(0, g.players[2].Hand)
+	is.True(g.players[2].IsDead())
+	is.Equal(g.Graveyard(), []uint8{CardAmbassador, CardCaptain, CardDuke})
+}
+
+func TestGameExchangePool(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador | cardRevealed, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+	is.Equal(g.ExchangePool(0), nil)
+
+	deck := len(g.deck)
+	is.NoErr(g.Claim(g.players[0], CardAmbassador))
+	is.NoErr(g.ClaimPass())
+	is.Equal(len(g.deck), deck-2)
+
+	pool := g.ExchangePool(0)
+	is.Equal(pool, []uint8{CardAssassin, g.exchange[0], g.exchange[1]})
+	is.Equal(len(g.LegalMoves(0)), len(exchangeMoves(0, CardAmbassador, pool, 1)))
+
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador, Cards: pool[:2]}), ErrInvalidExchange)
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador, Cards: pool[2:], AmbassadorHand: Hand{CardDuke, CardDuke}}))
+	is.Equal(g.primaryAction().AmbassadorHand, Hand{pool[1], pool[2]})
+
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Hand, Hand{CardAmbassador | cardRevealed, pool[2]})
+	is.Equal(len(g.deck), deck)
+	is.Equal(g.exchange, Hand{})
+	is.Equal(g.ExchangePool(0), nil)
+
+	// the exchange is private
+	last := g.HistoryFor(1)[len(g.history)-1]
+	is.Equal(last.Kind, ActionCharacter)
+	is.Equal(last.Cards, nil)
+	is.Equal(last.AmbassadorHand, Hand{})
+	is.Equal(g.HistoryFor(0)[len(g.history)-1].Cards, pool[2:])
+}
+
+// countCards returns the amount of cards in play; the deck, the live
+// cards of every player and the cards drawn for an exchange.
+func countCards(g *Game) int {
+	n := len(g.deck) + len(g.exchange.places())
+	for _, v := range g.players {
+		if v != nil {
+			n += len(v.Hand.places())
+		}
+	}
+
+	return n
+}
+
+func TestGameExchangeKind(t *testing.T) {
+	is := is.New(t)
+
+	pl := []*Player{{}, {}}
+	g, err := NewGameWithPlayers(pl, WithSeed(1))
+	is.NoErr(err)
+	total := countCards(g)
+
+	is.NoErr(g.Claim(pl[0], CardAmbassador))
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseExchange)
+	is.Equal(countCards(g), total)
+
+	// only the exchange is accepted, whatever its cards
+	pool := g.ExchangePool(0)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionIncome, Character: CardAmbassador, Cards: pool[2:]}), ErrInvalidAction)
+	is.Equal(g.Phase(), PhaseExchange)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador, Cards: pool[2:]}))
+	is.NoErr(g.DoAction())
+	is.Equal(countCards(g), total)
+}
+
+func TestGameExchangeTimeout(t *testing.T) {
+	is := is.New(t)
+
+	pl := []*Player{{}, {}}
+	g, err := NewGameWithPlayers(pl, WithSeed(1), WithInquisitor())
+	is.NoErr(err)
+	total := countCards(g)
+
+	// the drawn cards go back to the deck when the turn times out
+	is.NoErr(g.Claim(pl[0], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor}))
+	is.Equal(g.Phase(), PhaseExchange)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1), Cards: g.ExchangePool(0)[1:]}), ErrInvalidExchange)
+	g.NextTurn()
+	is.Equal(g.exchange, Hand{})
+	is.Equal(countCards(g), total)
+
+	g, err = NewGameWithPlayers(pl, WithSeed(1))
+	is.NoErr(err)
+
+	is.NoErr(g.Claim(pl[0], CardAmbassador))
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseExchange)
+	g.NextTurn()
+	is.Equal(countCards(g), total)
+}
+
+func TestGameClaimBlocker(t *testing.T) {
+	g := newTestGame(t, Hand{CardCaptain, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardAmbassador, CardCaptain})
+
+	is := is.New(t)
+
+	is.NoErr(g.Claim(g.players[0], CardCaptain))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(1)}))
+
+	is.Equal(g.Pending().Players(), []uint8{1})
+	is.Equal(g.Claim(g.players[2], CardAmbassador), ErrInvalidBlocker)
+	is.Equal(g.Pass(g.players[2]), ErrInvalidReactor)
+
+	// the target is the only one who has to pass
+	is.NoErr(g.Pass(g.players[1]))
+	is.Equal(g.Phase(), PhaseResolve)
+}
@@ -6,15 +6,31 @@ import (
 	"strings"
 )
 
+// cardRevealed is the bit set on a card of a Hand once the card has been
+// lost. A revealed card stays in the Hand face-up but is no longer an
+// influence of its player.
+const cardRevealed uint8 = 0x80
+
 // Hand is a slice that could contain two cards.
 type Hand [2]uint8
 
-// String returns a string version of hand.
+// String returns a string version of hand. Revealed cards are prefixed
+// with a "*", i.e. "*2:4" is a hand with a revealed Duke and a Captain.
 //
 // This function is particularily useful when paired with Hand.Unmarshal
 // because it allows database to store this value and unmarshal it
 // when wanted.
-func (h Hand) String() string { return fmt.Sprintf("%d:%d", h[0], h[1]) }
+func (h Hand) String() string {
+	str := [2]string{}
+	for k := range h {
+		str[k] = strconv.Itoa(int(h.Card(uint8(k))))
+		if h.IsRevealed(uint8(k)) {
+			str[k] = "*" + str[k]
+		}
+	}
+
+	return str[0] + ":" + str[1]
+}
 
 // Unmarshal reads the values in a string formatted by String and sets
 // the Hand's values to those read from the string.
@@ -24,27 +40,80 @@ func (h *Hand) Unmarshal(str string) error {
 		return fmt.Errorf("bad format")
 	}
 
-	fir, err := strconv.ParseUint(split[0], 10, 8)
-	if err != nil {
-		return err
-	}
+	hand := Hand{}
+	for k := range hand {
+		revealed := strings.HasPrefix(split[k], "*")
 
-	sec, err := strconv.ParseUint(split[1], 10, 8)
-	if err != nil {
-		return err
+		val, err := strconv.ParseUint(strings.TrimPrefix(split[k], "*"), 10, 8)
+		if err != nil {
+			return err
+		} else if uint8(val)&cardRevealed != 0 || (revealed && val == uint64(CardEmpty)) {
+			return fmt.Errorf("bad card")
+		}
+
+		hand[k] = uint8(val)
+		if revealed {
+			hand[k] |= cardRevealed
+		}
 	}
 
-	h[0], h[1] = uint8(fir), uint8(sec)
+	*h = hand
 
 	return nil
 }
 
-// IsEmpty returns true whenever Hand[0] and Hand[1] == CardEmpty
+// Card returns the character at place regardless of whether it has been
+// revealed or not.
+func (h Hand) Card(place uint8) uint8 { return h[place] &^ cardRevealed }
+
+// IsRevealed returns true if the card at place has been lost and is
+// face-up.
+func (h Hand) IsRevealed(place uint8) bool { return h[place]&cardRevealed != 0 }
+
+// Revealed returns the characters of every revealed card in the Hand.
+func (h Hand) Revealed() []uint8 {
+	arr := []uint8{}
+	for k := range h {
+		if h.IsRevealed(uint8(k)) {
+			arr = append(arr, h.Card(uint8(k)))
+		}
+	}
+
+	return arr
+}
+
+// IsEmpty returns true whenever the Hand has no influence left; that is
+// every card is either CardEmpty or revealed.
 func (h Hand) IsEmpty() bool {
-	return h[0] == CardEmpty && h[1] == CardEmpty
+	return len(h.places()) == 0
 }
 
 // IsEqual tests if the Hand is equal with another Hand.
 func (h Hand) IsEqual(v Hand) bool {
 	return h[0] == v[0] && h[1] == v[1]
 }
+
+// find returns the place of the first influence in the Hand whose
+// character is v. ok is false if there is none.
+func (h Hand) find(v uint8) (place uint8, ok bool) {
+	for _, k := range h.places() {
+		if h[k] == v {
+			return k, true
+		}
+	}
+
+	return 0, false
+}
+
+// places returns the indexes of every card in the Hand that is still an
+// influence; i.e. neither CardEmpty nor revealed.
+func (h Hand) places() []uint8 {
+	arr := []uint8{}
+	for k, v := range h {
+		if v != CardEmpty && v&cardRevealed == 0 {
+			arr = append(arr, uint8(k))
+		}
+	}
+
+	return arr
+}
@@ -9,6 +9,9 @@ func TestHandString(t *testing.T) {
 	h := Hand{0: 5, 1: 4}
 	is := is.New(t)
 	is.Equal(h.String(), "5:4")
+
+	h[0] |= cardRevealed
+	is.Equal(h.String(), "*5:4")
 }
 
 func TestHandUnmarshal(t *testing.T) {
@@ -21,6 +24,29 @@ func TestHandUnmarshal(t *testing.T) {
 	is.NoErr(h.Unmarshal("4:4"))
 
 	is.Equal(*h, Hand{4, 4})
+
+	is.True(h.Unmarshal("*0:4") != nil)
+	is.True(h.Unmarshal("128:4") != nil)
+	is.True(h.Unmarshal("**2:4") != nil)
+	is.NoErr(h.Unmarshal("*2:4"))
+	is.Equal(*h, Hand{CardDuke | cardRevealed, CardCaptain})
+
+	for _, v := range []Hand{{CardDuke, CardContessa | cardRevealed}, {CardAssassin | cardRevealed, CardEmpty}} {
+		is.NoErr(h.Unmarshal(v.String()))
+		is.Equal(*h, v)
+	}
+}
+
+func TestHandRevealed(t *testing.T) {
+	is := is.New(t)
+	h := Hand{CardDuke | cardRevealed, CardCaptain}
+
+	is.True(h.IsRevealed(0))
+	is.True(!h.IsRevealed(1))
+	is.Equal(h.Card(0), CardDuke)
+	is.Equal(h.Card(1), CardCaptain)
+	is.Equal(h.Revealed(), []uint8{CardDuke})
+	is.Equal(Hand{CardDuke, CardCaptain}.Revealed(), []uint8{})
 }
 
 func TestHandIsEmpty(t *testing.T) {
@@ -28,6 +54,8 @@ func TestHandIsEmpty(t *testing.T) {
 	empty := Hand{CardEmpty, CardEmpty}
 
 	is.True(empty.IsEmpty())
+	is.True(Hand{CardDuke | cardRevealed, CardEmpty}.IsEmpty())
+	is.True(Hand{CardDuke | cardRevealed, CardContessa | cardRevealed}.IsEmpty())
 
 	nonEmpty := [3]Hand{
 		Hand{CardEmpty, CardDuke},
@@ -56,3 +84,30 @@ func TestHandEqual(t *testing.T) {
 
 	is.Equal(equal, hand)
 }
+
+func TestHandFind(t *testing.T) {
+	is := is.New(t)
+
+	place, ok := Hand{CardDuke, CardCaptain}.find(CardCaptain)
+	is.True(ok)
+	is.Equal(place, uint8(1))
+
+	place, ok = Hand{CardDuke | cardRevealed, CardDuke}.find(CardDuke)
+	is.True(ok)
+	is.Equal(place, uint8(1))
+
+	_, ok = Hand{CardDuke | cardRevealed, CardEmpty}.find(CardDuke)
+	is.True(!ok)
+	_, ok = Hand{}.find(CardEmpty)
+	is.True(!ok)
+}
+
+func TestHandPlaces(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(Hand{CardDuke, CardEmpty}.places(), []uint8{0})
+	is.Equal(Hand{CardEmpty, CardDuke}.places(), []uint8{1})
+	is.Equal(Hand{CardDuke, CardDuke}.places(), []uint8{0, 1})
+	is.Equal(Hand{CardDuke | cardRevealed, CardDuke}.places(), []uint8{1})
+	is.Equal(Hand{}.places(), []uint8{})
+}
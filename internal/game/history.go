@@ -0,0 +1,129 @@
+package game
+
+import "time"
+
+// Entry is a structure holding an Action of the history along with when
+// it happened and what it responds to. See Game.History.
+type Entry struct {
+	Action
+	// Seq is the index of the Entry in the history; it grows by one with
+	// every Entry.
+	Seq uint64 `json:"seq"`
+	// Time is the time at which the Entry was added to the history.
+	Time time.Time `json:"time"`
+	// Turn is the number of the turn the Entry belongs to. The deal and
+	// the first turn are turn 0; every call to Game.NextTurn adds 1.
+	Turn uint `json:"turn"`
+	// Cause is the Seq of the Entry this one responds to; nil if it
+	// responds to none. A claim is responded to by its challenge, a
+	// challenge by its proof, and a proof by the punishment and the new
+	// card of the claimant. See Game.ClaimProve.
+	Cause *uint64 `json:"cause,omitempty"`
+}
+
+// clone returns a copy of the Entry that shares no memory with it.
+func (e Entry) clone() Entry {
+	e.Cards = cloneCards(e.Cards)
+	if e.AgainstID != nil {
+		e.AgainstID = newUint8(*e.AgainstID)
+	}
+	if e.AssassinPlace != nil {
+		e.AssassinPlace = newUint8(*e.AssassinPlace)
+	}
+	if e.Cause != nil {
+		cause := *e.Cause
+		e.Cause = &cause
+	}
+
+	return e
+}
+
+// addEntry is a function that adds a to the history as a response to the
+// Entry at cause, if any, and returns the Seq of its Entry.
+func (g *Game) addEntry(a Action, cause *uint64) uint64 {
+	g.historyMtx.Lock()
+	defer g.historyMtx.Unlock()
+
+	seq := uint64(len(g.history))
+	g.history = append(g.history, Entry{Action: a, Seq: seq, Time: time.Now(), Turn: g.turns, Cause: cause})
+
+	return seq
+}
+
+// addResponseToHistory is the same as addActionToHistory but the Entry
+// responds to the Entry at cause.
+func (g *Game) addResponseToHistory(a Action, cause uint64) uint64 {
+	return g.addEntry(a, &cause)
+}
+
+// turnNumber returns the number of the current turn. See Entry.Turn.
+func (g *Game) turnNumber() uint {
+	g.historyMtx.Lock()
+	defer g.historyMtx.Unlock()
+
+	return g.turns
+}
+
+// isPrivate returns true if the Action holds cards that only its author
+// is allowed to see; like the starting hand of ActionDeal, the new card
+// of ActionClaimTakeCard, the picked hand of ActionHandSelection, the
+// shown card of ActionShowCard or an exchange with the deck.
+func isPrivate(a Action) bool {
+	return a.Kind == ActionDeal || a.Kind == ActionClaimTakeCard ||
+		a.Kind == ActionHandSelection || a.Kind == ActionShowCard ||
+		a.Kind == ActionExchangeDraw || isExchange(a)
+}
+
+// isVisibleTo returns true if the player at index may see the cards of
+// the private Action; its author, or the Inquisitor a card was shown to.
+func isVisibleTo(a Action, index int) bool {
+	if int(a.AuthorID) == index {
+		return true
+	}
+
+	return a.Kind == ActionShowCard && a.AgainstID != nil && int(*a.AgainstID) == index
+}
+
+// HistoryFor returns a copy of the Game's history as seen by the player at
+// index. Private Actions of other players have their Cards and
+// AmbassadorHand removed; except for the cards shown to an Inquisitor. A
+// negative index returns the history as seen by a spectator.
+func (g *Game) HistoryFor(index int) []Entry {
+	arr := g.History(0, -1)
+	for k, v := range arr {
+		if isPrivate(v.Action) && !isVisibleTo(v.Action, index) {
+			arr[k].Cards, arr[k].AmbassadorHand = nil, Hand{}
+		}
+	}
+
+	return arr
+}
+
+// History returns a copy of the entries of the Game's history from Seq
+// from up to, but excluding, Seq to. A negative to, or one past the end
+// of the history, stops at its end. An empty range returns an empty
+// slice.
+//
+// Do note: History holds the private cards of every player. See
+//          Game.HistoryFor for the history as seen by one of them.
+func (g *Game) History(from, to int) []Entry {
+	g.historyMtx.Lock()
+	defer g.historyMtx.Unlock()
+
+	if to < 0 || to > len(g.history) {
+		to = len(g.history)
+	}
+	if from < 0 {
+		from = 0
+	}
+	if from > to {
+		from = to
+	}
+
+	arr := make([]Entry, 0, to-from)
+	for _, v := range g.history[from:to] {
+		arr = append(arr, v.clone())
+	}
+
+	return arr
+}
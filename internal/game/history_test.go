@@ -0,0 +1,184 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestIsPrivate(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isPrivate(Action{Kind: ActionDeal}))
+	is.True(isPrivate(Action{Kind: ActionClaimTakeCard}))
+	is.True(isPrivate(Action{Kind: ActionHandSelection}))
+	is.True(isPrivate(Action{Kind: ActionShowCard}))
+	is.True(isPrivate(Action{Kind: ActionExchangeDraw}))
+	is.True(isPrivate(Action{Kind: ActionCharacter, Character: CardInquisitor}))
+	is.True(!isPrivate(Action{Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}))
+	is.True(!isPrivate(Action{Kind: ActionClaimProof}))
+	is.True(!isPrivate(Action{Kind: ActionInfluenceLoss}))
+}
+
+func TestIsVisibleTo(t *testing.T) {
+	is := is.New(t)
+
+	is.True(isVisibleTo(Action{AuthorID: 1, Kind: ActionDeal}, 1))
+	is.True(!isVisibleTo(Action{AuthorID: 1, Kind: ActionDeal, AgainstID: newUint8(0)}, 0))
+	is.True(isVisibleTo(Action{AuthorID: 1, Kind: ActionShowCard, AgainstID: newUint8(0)}, 0))
+	is.True(!isVisibleTo(Action{AuthorID: 1, Kind: ActionShowCard, AgainstID: newUint8(0)}, 2))
+	is.True(!isVisibleTo(Action{AuthorID: 1, Kind: ActionShowCard}, -1))
+}
+
+func TestGameHistoryFor(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	g.players[0].Coins = g.rules.AssassinCost
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.NoErr(g.ClaimChallenge(g.players[1]))
+	_, err := g.ClaimProve(CardAssassin)
+	is.NoErr(err)
+
+	owner := g.HistoryFor(0)
+	is.Equal(owner[len(owner)-1].Cards, []uint8{g.players[0].Hand[1]})
+
+	for _, index := range []int{0, 1, -1} {
+		history := g.HistoryFor(index)
+		is.Equal(len(history), len(g.history))
+
+		for k, v := range history {
+			if isPrivate(v.Action) && int(v.AuthorID) != index {
+				is.Equal(v.Cards, nil)
+			} else {
+				is.Equal(v, g.history[k])
+			}
+		}
+	}
+
+	is.Equal(g.HistoryFor(1)[len(owner)-1].Cards, nil)
+	is.True(g.history[len(owner)-1].Cards != nil)
+}
+
+func TestGameHistory(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionIncome}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	history := g.History(0, -1)
+	is.Equal(len(history), len(g.history))
+	for k, v := range history {
+		is.Equal(v.Seq, uint64(k))
+		is.True(!v.Time.IsZero())
+	}
+	is.Equal(history[len(history)-1].Kind, ActionIncome)
+	is.Equal(history[len(history)-1].Turn, uint(0))
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionIncome}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.history[len(g.history)-1].Turn, uint(1))
+
+	// the range is clamped to the history
+	is.Equal(len(g.History(1, 2)), 1)
+	is.Equal(g.History(1, 2)[0].Seq, uint64(1))
+	is.Equal(len(g.History(-1, 100)), len(g.history))
+	is.Equal(g.History(3, 1), []Entry{})
+
+	// the copy doesn't share the history's memory
+	copied := g.History(0, 1)
+	copied[0].Cards[0] = CardEmpty
+	is.True(g.history[0].Cards[0] != CardEmpty)
+}
+
+func TestEntryCause(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	last := func() Entry { return g.history[len(g.history)-1] }
+
+	is.NoErr(g.Claim(g.players[0], CardDuke))
+	claim := last()
+	is.Equal(claim.Cause, nil)
+
+	is.NoErr(g.ClaimChallenge(g.players[1]))
+	challenge := last()
+	is.Equal(*challenge.Cause, claim.Seq)
+
+	// the proof is found through the claim, whatever came after the
+	// challenge
+	g.addActionToHistory(Action{AuthorID: 1, Kind: ActionIncome})
+
+	_, err := g.ClaimProve(CardDuke)
+	is.NoErr(err)
+	proof := g.history[len(g.history)-2]
+	is.Equal(proof.Action, Action{
+		AuthorID:  0,
+		author:    g.players[0],
+		AgainstID: newUint8(1),
+		against:   g.players[1],
+		Kind:      ActionClaimProof,
+		Character: CardDuke,
+	})
+	is.Equal(*proof.Cause, challenge.Seq)
+	is.Equal(last().Kind, ActionClaimTakeCard)
+	is.Equal(*last().Cause, proof.Seq)
+
+	is.NoErr(g.LoseInfluence(g.players[1], 0))
+	is.Equal(last().Kind, ActionInfluenceLoss)
+	is.Equal(*last().Cause, proof.Seq)
+
+	// the Duke's Action is declared in response to its claim, and done in
+	// response to its declaration
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardDuke}))
+	declared := last()
+	is.True(declared.Declared)
+	is.Equal(*declared.Cause, claim.Seq)
+	is.NoErr(g.DoAction())
+	is.True(!last().Declared)
+	is.Equal(*last().Cause, declared.Seq)
+}
+
+func TestEntryCauseBlock(t *testing.T) {
+	g := newTestGame(t, Hand{CardAssassin, CardDuke}, Hand{CardContessa, CardDuke})
+
+	is := is.New(t)
+
+	last := func() Entry { return g.history[len(g.history)-1] }
+
+	g.players[0].Coins = DefaultRules().AssassinCost
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.NoErr(g.ClaimPass())
+
+	// the assassination is stored as soon as it is declared
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin, AgainstID: newUint8(1)}))
+	declared := last()
+	is.Equal(declared.Action, Action{
+		AuthorID:  0,
+		author:    g.players[0],
+		AgainstID: newUint8(1),
+		against:   g.players[1],
+		Kind:      ActionCharacter,
+		Character: CardAssassin,
+		Declared:  true,
+	})
+
+	// the block responds to it
+	is.NoErr(g.Claim(g.players[1], CardContessa))
+	block := last()
+	is.Equal(*block.Cause, declared.Seq)
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.DoAction())
+
+	is.Equal(last().Kind, ActionCharacter)
+	is.Equal(last().Character, CardContessa)
+	is.Equal(*last().Cause, block.Seq)
+	for _, v := range g.history[declared.Seq+1:] {
+		is.True(v.Character != CardAssassin)
+	}
+}
@@ -0,0 +1,100 @@
+package game
+
+// influenceLoss is a structure describing a player that must choose
+// which of their cards to lose.
+type influenceLoss struct {
+	victim *Player
+	// challenge is true if the victim lost a challenge. Once the card is
+	// lost, the Game carries on with whatever comes after the claim.
+	// Otherwise, the victim was the target of a Coup or an assassination
+	// and the turn ends.
+	challenge bool
+	// cause is the Seq of the Entry the loss responds to; the proof of
+	// the challenge or the Action that took the influence.
+	cause uint64
+}
+
+// setLoss makes the victim lose an influence and moves the Game to
+// PhaseInfluenceLoss. If the victim has a single card left, there is
+// nothing to choose; the card is lost right away. If the victim has no
+// cards left, setLoss only carries on with the Game. The lost card
+// responds to the Entry at cause.
+//
+// setLoss must be called while holding stackMtx.
+func (g *Game) setLoss(victim *Player, challenge bool, cause uint64) {
+	g.loss = &influenceLoss{victim: victim, challenge: challenge, cause: cause}
+
+	places := victim.Hand.places()
+	switch len(places) {
+	case 0:
+		g.resolveLoss()
+	case 1:
+		g.loseInfluence(places[0])
+	default:
+		g.setPhase(PhaseInfluenceLoss)
+	}
+}
+
+// loseInfluence removes the card at place from the victim's hand, stores
+// it in the history as an ActionInfluenceLoss and carries on with the
+// Game.
+//
+// loseInfluence must be called while holding stackMtx.
+func (g *Game) loseInfluence(place uint8) {
+	victim := g.loss.victim
+	index := findPlayerByPntr(g.players, victim)
+
+	g.addResponseToHistory(Action{
+		AuthorID:      uint8(index),
+		Kind:          ActionInfluenceLoss,
+		Character:     victim.Hand[place],
+		AssassinPlace: &place,
+	}, g.loss.cause)
+
+	victim.Hand = removeFromHand(place, victim.Hand)
+	g.resolveLoss()
+}
+
+// resolveLoss clears the influence loss and carries on with the Game.
+//
+// resolveLoss must be called while holding stackMtx.
+func (g *Game) resolveLoss() {
+	loss := g.loss
+	g.loss = nil
+
+	if !loss.challenge {
+		g.endTurn()
+		return
+	}
+
+	succeed, _ := g.currentClaim().Results()
+	g.resolveClaim(*succeed)
+}
+
+// LoseInfluence is a function that lets the victim of a Coup, of an
+// assassination or of a lost challenge choose which card they lose. The
+// lost card is stored in the history as an ActionInfluenceLoss.
+//
+// LoseInfluence returns ErrInvalidLossPlayer if the player is not the
+// victim, and ErrInvalidActionPlace if there is no card at place.
+func (g *Game) LoseInfluence(player *Player, place uint8) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	if g.Phase() != PhaseInfluenceLoss || g.loss == nil {
+		return ErrInvalidPhase
+	} else if player == nil || player != g.loss.victim {
+		return ErrInvalidLossPlayer
+	} else if place > 1 || player.Hand[place] == CardEmpty || player.Hand.IsRevealed(place) {
+		return ErrInvalidActionPlace
+	}
+
+	g.loseInfluence(place)
+	g.updateEliminations()
+
+	return nil
+}
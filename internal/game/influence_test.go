@@ -0,0 +1,70 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameSetLoss(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardEmpty}, Hand{})
+
+	is := is.New(t)
+
+	g.phase = PhaseResolve
+	g.setLoss(g.players[0], false, 0)
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+	is.Equal(g.loss.victim, g.players[0])
+
+	// a single card is lost without a choice
+	g.setLoss(g.players[1], false, 0)
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(g.loss, nil)
+	is.Equal(g.players[1].Hand, Hand{CardDuke | cardRevealed, CardEmpty})
+	is.Equal(g.history[len(g.history)-1].Action, Action{
+		AuthorID:      1,
+		Kind:          ActionInfluenceLoss,
+		Character:     CardDuke,
+		AssassinPlace: newUint8(0),
+	})
+
+	// no cards, nothing to lose
+	g.phase = PhaseResolve
+	length := len(g.history)
+	g.setLoss(g.players[2], false, 0)
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(len(g.history), length)
+}
+
+func TestGameLoseInfluence(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	is.Equal(g.LoseInfluence(g.players[1], 0), ErrInvalidPhase)
+
+	g.players[0].Coins = 3
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.NoErr(g.ClaimPass())
+
+	place, against := uint8(0), uint8(1)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin}), ErrInvalidActionAgainst)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin, AgainstID: &against, AssassinPlace: &place}), ErrInvalidActionPlaceChoice)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin, AgainstID: &against}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Coins, uint8(0))
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+
+	is.Equal(g.LoseInfluence(g.players[0], 0), ErrInvalidLossPlayer)
+	is.Equal(g.LoseInfluence(nil, 0), ErrInvalidLossPlayer)
+	is.Equal(g.LoseInfluence(g.players[1], 2), ErrInvalidActionPlace)
+
+	is.NoErr(g.LoseInfluence(g.players[1], 1))
+	is.Equal(g.players[1].Hand, Hand{CardDuke, CardContessa | cardRevealed})
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(g.history[len(g.history)-1].Character, CardContessa)
+
+	g.NextTurn()
+	is.Equal(g.Action(Action{AuthorID: 1, Kind: ActionCoup, AgainstID: newUint8(0)}), ErrInvalidActionCoins)
+}
@@ -0,0 +1,123 @@
+package game
+
+// examination is a structure describing an Inquisitor examining a card
+// of their target.
+type examination struct {
+	inquisitor *Player
+	target     *Player
+	// place is the place of the shown card; nil until the target has
+	// shown it.
+	place *uint8
+	// cause is the Seq of the Entry the next step of the examination
+	// responds to; the Inquisitor's Action, then the shown card.
+	cause uint64
+}
+
+// setExamination makes the target of the Inquisitor's Action show a card
+// and moves the Game to PhaseShowCard. If the target has a single card
+// left, there is nothing to choose; the card is shown right away. The
+// shown card responds to the Entry of the Action at seq.
+//
+// setExamination must be called while holding stackMtx.
+func (g *Game) setExamination(a Action, seq uint64) {
+	g.examination = &examination{inquisitor: a.author, target: a.against, cause: seq}
+
+	places := a.against.Hand.places()
+	if len(places) == 1 {
+		g.showCard(places[0])
+		return
+	}
+
+	g.setPhase(PhaseShowCard)
+}
+
+// showCard shows the card at place to the Inquisitor, stores it in the
+// history as an ActionShowCard and moves the Game to PhaseExamine.
+//
+// showCard must be called while holding stackMtx.
+func (g *Game) showCard(place uint8) {
+	e := g.examination
+	inquisitor := uint8(findPlayerByPntr(g.players, e.inquisitor))
+
+	e.place = &place
+	e.cause = g.addResponseToHistory(Action{
+		AuthorID:      uint8(findPlayerByPntr(g.players, e.target)),
+		AgainstID:     &inquisitor,
+		Kind:          ActionShowCard,
+		AssassinPlace: &place,
+		Cards:         []uint8{e.target.Hand[place]},
+	}, e.cause)
+
+	g.setPhase(PhaseExamine)
+}
+
+// ShowCard is a function that lets the target of an Inquisitor choose
+// which card they show to the Inquisitor. The shown card is stored in
+// the history as an ActionShowCard that only the target and the
+// Inquisitor can see. See Game.HistoryFor.
+//
+// ShowCard returns ErrInvalidExaminee if the player is not the target,
+// and ErrInvalidActionPlace if there is no card at place.
+func (g *Game) ShowCard(player *Player, place uint8) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	if g.Phase() != PhaseShowCard || g.examination == nil {
+		return ErrInvalidPhase
+	} else if player == nil || player != g.examination.target {
+		return ErrInvalidExaminee
+	} else if place > 1 || player.Hand[place] == CardEmpty || player.Hand.IsRevealed(place) {
+		return ErrInvalidActionPlace
+	}
+
+	g.showCard(place)
+
+	return nil
+}
+
+// Examine is a function that lets the Inquisitor decide what happens to
+// the card their target has shown. If swap is true, the target shuffles
+// the card back into the deck and draws a new one in its place; see
+// ActionClaimTakeCard. Otherwise, the target keeps it. The decision is
+// stored in the history as an ActionExamine and the turn ends.
+//
+// Examine returns ErrInvalidExaminer if the player is not the Inquisitor.
+func (g *Game) Examine(player *Player, swap bool) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	e := g.examination
+	if g.Phase() != PhaseExamine || e == nil {
+		return ErrInvalidPhase
+	} else if player == nil || player != e.inquisitor {
+		return ErrInvalidExaminer
+	}
+
+	target := uint8(findPlayerByPntr(g.players, e.target))
+	a := Action{
+		AuthorID:  uint8(findPlayerByPntr(g.players, player)),
+		AgainstID: &target,
+		Kind:      ActionExamine,
+	}
+
+	if swap {
+		a.AssassinPlace = e.place
+	}
+	seq := g.addResponseToHistory(a, e.cause)
+
+	if swap {
+		g.takeCards(e.target, []uint8{*e.place}, seq)
+	}
+
+	g.endTurn()
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// newInquisitorGame is the same as newTestGame but the Inquisitor
+// replaces the Ambassador.
+func newInquisitorGame(t *testing.T, hands ...Hand) *Game {
+	pl := [5]*Player{}
+	for k := range hands {
+		pl[k] = &Player{}
+	}
+
+	g, err := NewGame(pl, WithInquisitor(), WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range hands {
+		g.players[k].Hand = v
+	}
+
+	return g
+}
+
+// examine makes the player at index claim the Inquisitor and examine the
+// player at target.
+func examine(t *testing.T, g *Game, index, target uint8) {
+	is := is.New(t)
+
+	is.NoErr(g.Claim(g.players[index], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: index, Kind: ActionCharacter, Character: CardInquisitor, AgainstID: &target}))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+}
+
+func TestGameInquisitorExchange(t *testing.T) {
+	g := newInquisitorGame(t, Hand{CardInquisitor, CardDuke}, Hand{CardCaptain, CardContessa})
+
+	is := is.New(t)
+
+	is.Equal(g.Claim(g.players[0], CardAmbassador), ErrInvalidCharacter)
+	is.NoErr(g.Claim(g.players[0], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseAction)
+
+	exchange := Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor}
+	is.Equal(g.LegalMoves(0), []Move{
+		{Input: InputAction, Action: &exchange},
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}},
+	})
+
+	is.NoErr(g.Action(exchange))
+	is.Equal(g.Phase(), PhaseExchange)
+
+	drawn := g.exchange[0]
+	is.Equal(g.ExchangePool(0), []uint8{CardInquisitor, CardDuke, drawn})
+	is.Equal(len(g.LegalMoves(0)), len(exchangeMoves(0, CardInquisitor, g.ExchangePool(0), 2)))
+
+	exchange.Cards = []uint8{drawn, CardDuke}
+	is.NoErr(g.Action(exchange))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+
+	is.Equal(g.players[0].Hand, Hand{drawn, CardDuke})
+	is.Equal(len(g.deck), 11)
+	is.Equal(g.HistoryFor(1)[len(g.history)-1].Cards, nil)
+}
+
+func TestGameSetExamination(t *testing.T) {
+	g := newInquisitorGame(t, Hand{CardInquisitor, CardDuke}, Hand{CardCaptain | cardRevealed, CardContessa})
+
+	is := is.New(t)
+
+	// a single card is shown right away
+	examine(t, g, 0, 1)
+	is.Equal(g.Phase(), PhaseExamine)
+	is.Equal(*g.examination.place, uint8(1))
+	is.Equal(g.history[len(g.history)-1].Action, Action{
+		AuthorID:      1,
+		AgainstID:     newUint8(0),
+		Kind:          ActionShowCard,
+		AssassinPlace: newUint8(1),
+		Cards:         []uint8{CardContessa},
+	})
+}
+
+func TestGameShowCard(t *testing.T) {
+	g := newInquisitorGame(t, Hand{CardInquisitor, CardDuke}, Hand{CardCaptain, CardContessa}, Hand{CardDuke, CardDuke})
+
+	is := is.New(t)
+
+	is.Equal(g.ShowCard(g.players[1], 0), ErrInvalidPhase)
+
+	examine(t, g, 0, 1)
+	is.Equal(g.Phase(), PhaseShowCard)
+	is.Equal(g.Pending().Decisions, []Decision{{Player: 1, Inputs: []uint8{InputShowCard}}})
+	is.Equal(g.LegalMoves(1), []Move{
+		{Input: InputShowCard, Character: CardCaptain, Place: 0},
+		{Input: InputShowCard, Character: CardContessa, Place: 1},
+	})
+
+	is.Equal(g.ShowCard(g.players[0], 0), ErrInvalidExaminee)
+	is.Equal(g.ShowCard(g.players[1], 2), ErrInvalidActionPlace)
+	is.NoErr(g.ShowCard(g.players[1], 0))
+	is.Equal(g.Phase(), PhaseExamine)
+
+	// only the target and the Inquisitor see the shown card
+	is.Equal(g.HistoryFor(0)[len(g.history)-1].Cards, []uint8{CardCaptain})
+	is.Equal(g.HistoryFor(1)[len(g.history)-1].Cards, []uint8{CardCaptain})
+	is.Equal(g.HistoryFor(2)[len(g.history)-1].Cards, nil)
+	is.Equal(g.HistoryFor(-1)[len(g.history)-1].Cards, nil)
+}
+
+func TestGameExamine(t *testing.T) {
+	g := newInquisitorGame(t, Hand{CardInquisitor, CardDuke}, Hand{CardCaptain, CardContessa})
+
+	is := is.New(t)
+
+	examine(t, g, 0, 1)
+	is.NoErr(g.ShowCard(g.players[1], 1))
+
+	is.Equal(g.Pending().Decisions, []Decision{{Player: 0, Inputs: []uint8{InputExamine}}})
+	is.Equal(g.LegalMoves(0), []Move{{Input: InputExamine}, {Input: InputExamine, Swap: true}})
+
+	is.Equal(g.Examine(g.players[1], true), ErrInvalidExaminer)
+	is.NoErr(g.Examine(g.players[0], true))
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(g.examination, nil)
+
+	is.Equal(g.history[len(g.history)-2].Action, Action{
+		AuthorID:      0,
+		AgainstID:     newUint8(1),
+		Kind:          ActionExamine,
+		AssassinPlace: newUint8(1),
+	})
+	is.Equal(g.history[len(g.history)-1].Kind, ActionClaimTakeCard)
+	is.Equal(g.history[len(g.history)-1].Cards, []uint8{g.players[1].Hand[1]})
+
+	// the target keeps the card
+	g.NextTurn()
+	g.NextTurn()
+	hand := g.players[1].Hand
+
+	examine(t, g, 0, 1)
+	is.NoErr(g.ShowCard(g.players[1], 0))
+	is.NoErr(g.Examine(g.players[0], false))
+	is.Equal(g.history[len(g.history)-1].Action, Action{AuthorID: 0, AgainstID: newUint8(1), Kind: ActionExamine})
+	is.Equal(g.players[1].Hand, hand)
+	is.Equal(g.Examine(g.players[0], false), ErrInvalidPhase)
+}
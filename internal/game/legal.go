@@ -0,0 +1,263 @@
+package game
+
+// Move is a structure that describes a single input a player could
+// submit to the Game right now.
+//
+// For InputAction, a nil Action means that the move is a Claim of
+// Character; otherwise Action is meant to be passed to Game.Action.
+// InputBlock, InputProve and InputSelectHand moves only set Character,
+// InputExchange moves always set Action, InputLoseInfluence and
+// InputShowCard moves set both Place and the Character found at Place and
+// InputExamine moves only set Swap.
+type Move struct {
+	Input     uint8   `json:"input"`
+	Character uint8   `json:"character,omitempty"`
+	Place     uint8   `json:"place,omitempty"`
+	Action    *Action `json:"action,omitempty"`
+	Swap      bool    `json:"swap,omitempty"`
+}
+
+// LegalMoves returns every move the player at index could submit right
+// now. It returns an empty slice if the Game isn't waiting on the player.
+//
+// LegalMoves respects the cost of every Action, the coins left in the
+// treasury, the counters of every character, the living targets of the
+// Game and the mandatory Coup. With the Reformation expansion, it also
+// respects the factions of the players and only offers ActionEmbezzle
+// while the reserve holds coins. The Game accepts the very same moves and
+// rejects every other one.
+func (g *Game) LegalMoves(index int) []Move {
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	moves := []Move{}
+	if index < 0 || index >= g.max || g.players[index] == nil {
+		return moves
+	}
+
+	for _, decision := range g.pending().Decisions {
+		if int(decision.Player) != index {
+			continue
+		}
+
+		for _, input := range decision.Inputs {
+			switch input {
+			case InputAction:
+				moves = append(moves, g.actionMoves(index)...)
+			case InputExchange:
+				moves = append(moves, exchangeMoves(uint8(index), g.currentClaim().character, g.exchangePool(index), len(g.players[index].Hand.places()))...)
+			case InputSelectHand:
+				for _, character := range g.selectionFor(index) {
+					moves = append(moves, Move{Input: input, Character: character})
+				}
+			case InputPass, InputChallenge:
+				moves = append(moves, Move{Input: input})
+			case InputBlock:
+				for _, character := range decision.Characters {
+					moves = append(moves, Move{Input: input, Character: character})
+				}
+			case InputProve:
+				hand := g.players[index].Hand
+				for _, place := range hand.places() {
+					moves = append(moves, Move{Input: input, Character: hand[place]})
+				}
+			case InputExamine:
+				moves = append(moves, Move{Input: input}, Move{Input: input, Swap: true})
+			case InputLoseInfluence, InputShowCard:
+				hand := g.players[index].Hand
+				for _, place := range hand.places() {
+					moves = append(moves, Move{Input: input, Character: hand[place], Place: place})
+				}
+			}
+		}
+	}
+
+	return moves
+}
+
+// actionMoves returns the claims and the primary actions available to
+// the player at index during PhaseAction.
+//
+// actionMoves must be called while holding stackMtx.
+func (g *Game) actionMoves(index int) []Move {
+	author := uint8(index)
+	coins := g.players[index].Coins
+	targets := g.targets(index)
+
+	treasury := g.Treasury()
+	affordable := func(a Action) bool {
+		a.author = g.players[index]
+		return coins >= actionCost(g.rules, a) && treasury >= payout(g.rules, a)
+	}
+
+	untargeted := func(kind, character uint8) []Move {
+		a := Action{AuthorID: author, Kind: kind, Character: character}
+		if !affordable(a) {
+			return []Move{}
+		}
+
+		return []Move{{Input: InputAction, Action: &a}}
+	}
+
+	targeted := func(kind, character uint8) []Move {
+		moves := []Move{}
+		if !affordable(Action{Kind: kind, Character: character}) {
+			return moves
+		}
+
+		for _, target := range targets {
+			moves = append(moves, Move{Input: InputAction, Action: &Action{
+				AuthorID:  author,
+				Kind:      kind,
+				Character: character,
+				AgainstID: newUint8(target),
+			}})
+		}
+
+		return moves
+	}
+
+	// characterMoves returns the Actions of the character. See
+	// Character.Acts.
+	characterMoves := func(character uint8) []Move {
+		moves := []Move{}
+		c, ok := CharacterOf(character)
+		if !ok {
+			return moves
+		}
+
+		if c.Acts(false) {
+			moves = append(moves, untargeted(ActionCharacter, character)...)
+		}
+		if c.Acts(true) {
+			moves = append(moves, targeted(ActionCharacter, character)...)
+		}
+
+		return moves
+	}
+
+	// the claim has passed; only its character's action is left.
+	if c := g.currentClaim(); c != nil {
+		return characterMoves(c.character)
+	}
+
+	coups := targeted(ActionCoup, 0)
+	if g.rules.mustCoup(coins) {
+		return coups
+	}
+
+	moves := untargeted(ActionIncome, 0)
+	moves = append(moves, untargeted(ActionFinancialAid, 0)...)
+	moves = append(moves, coups...)
+
+	for _, character := range g.characters() {
+		if g.claimable(index, character) == nil {
+			moves = append(moves, Move{Input: InputAction, Character: character})
+		}
+	}
+
+	if !g.reformation {
+		return moves
+	}
+
+	if coins >= g.rules.ConvertCost {
+		moves = append(moves, Move{Input: InputAction, Action: &Action{AuthorID: author, Kind: ActionConvert}})
+	}
+	if coins >= g.rules.ConvertOtherCost {
+		for _, target := range g.livingPlayers(index) {
+			moves = append(moves, Move{Input: InputAction, Action: &Action{
+				AuthorID:  author,
+				Kind:      ActionConvert,
+				AgainstID: newUint8(target),
+			}})
+		}
+	}
+	if g.Reserve() > 0 {
+		moves = append(moves, Move{Input: InputAction, Action: &Action{AuthorID: author, Kind: ActionEmbezzle}})
+	}
+
+	return moves
+}
+
+// targets returns the players that the player at index could target.
+//
+// targets must be called while holding stackMtx.
+func (g *Game) targets(index int) []uint8 {
+	arr := []uint8{}
+	for _, target := range g.livingPlayers(index) {
+		if g.mayTarget(uint8(index), target) {
+			arr = append(arr, target)
+		}
+	}
+
+	return arr
+}
+
+// claimable returns an error unless the player at index could play the
+// Action of the character once its claim holds up; i.e. the character has
+// an Action, the player and the treasury can pay for it and, if it must
+// have a target, a player can be targeted. See Character.Acts.
+//
+// claimable must be called while holding stackMtx.
+func (g *Game) claimable(index int, character uint8) error {
+	c, ok := CharacterOf(character)
+	if !ok {
+		return ErrInvalidCharacter
+	} else if !c.Acts(false) && !c.Acts(true) {
+		return ErrInvalidClaimNoAction
+	}
+
+	a := Action{AuthorID: uint8(index), author: g.players[index], Kind: ActionCharacter, Character: character}
+	if a.author.Coins < actionCost(g.rules, a) {
+		return ErrInvalidActionCoins
+	} else if g.Treasury() < payout(g.rules, a) {
+		return ErrInsufficientTreasury
+	} else if !c.Acts(false) && len(g.targets(index)) == 0 {
+		return ErrInvalidClaimNoTarget
+	}
+
+	return nil
+}
+
+// exchangeMoves returns every distinct set of n cards the author of the
+// character's exchange could keep out of pool. See AmbassadorAction.
+func exchangeMoves(author uint8, character uint8, pool []uint8, n int) []Move {
+	moves := []Move{}
+	seen := map[[2]uint8]bool{}
+
+	add := func(keep ...uint8) {
+		key := [2]uint8{}
+		copy(key[:], keep)
+		if key[0] > key[1] {
+			key[0], key[1] = key[1], key[0]
+		}
+
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+
+		moves = append(moves, Move{Input: InputExchange, Action: &Action{
+			AuthorID:  author,
+			Kind:      ActionCharacter,
+			Character: character,
+			Cards:     keep,
+		}})
+	}
+
+	for first := range pool {
+		if n == 1 {
+			add(pool[first])
+			continue
+		}
+
+		for second := first + 1; second < len(pool); second++ {
+			add(pool[first], pool[second])
+		}
+	}
+
+	return moves
+}
+
+// newUint8 returns a pointer to a copy of v.
+func newUint8(v uint8) *uint8 { return &v }
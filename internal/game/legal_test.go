@@ -0,0 +1,361 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNewUint8(t *testing.T) {
+	is := is.New(t)
+
+	a, b := newUint8(1), newUint8(1)
+	is.Equal(*a, uint8(1))
+	is.True(a != b)
+}
+
+func TestExchangeMoves(t *testing.T) {
+	is := is.New(t)
+
+	pool := []uint8{CardAmbassador, CardContessa, CardDuke, CardAssassin}
+	moves := exchangeMoves(1, CardAmbassador, pool, 2)
+	is.Equal(len(moves), 6)
+	for _, v := range moves {
+		is.Equal(v.Input, InputExchange)
+		is.Equal(v.Action.AuthorID, uint8(1))
+		is.Equal(v.Action.Character, CardAmbassador)
+		is.Equal(len(v.Action.Cards), 2)
+	}
+
+	// duplicates are only listed once
+	moves = exchangeMoves(1, CardAmbassador, []uint8{CardDuke, CardDuke, CardDuke, CardContessa}, 2)
+	is.Equal(len(moves), 2)
+	is.Equal(moves[0].Action.Cards, []uint8{CardDuke, CardDuke})
+	is.Equal(moves[1].Action.Cards, []uint8{CardDuke, CardContessa})
+
+	moves = exchangeMoves(1, CardAmbassador, []uint8{CardDuke, CardDuke, CardContessa}, 1)
+	is.Equal(len(moves), 2)
+	is.Equal(exchangeMoves(1, CardAmbassador, nil, 2), []Move{})
+}
+
+func TestGameLegalMoves(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardDuke, CardEmpty})
+
+	is := is.New(t)
+
+	is.Equal(g.LegalMoves(-1), []Move{})
+	is.Equal(g.LegalMoves(5), []Move{})
+	is.Equal(g.LegalMoves(1), []Move{})
+
+	is.Equal(g.LegalMoves(0), []Move{
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionIncome}},
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionFinancialAid}},
+		{Input: InputAction, Character: CardDuke},
+		{Input: InputAction, Character: CardAmbassador},
+		{Input: InputAction, Character: CardCaptain},
+	})
+
+	g.players[0].Coins = 3
+	is.Equal(len(g.LegalMoves(0)), 6)
+
+	g.players[0].Coins = 7
+	is.Equal(len(g.LegalMoves(0)), 8)
+
+	g.players[0].Coins = 10
+	moves := g.LegalMoves(0)
+	is.Equal(len(moves), 2)
+	for _, v := range moves {
+		is.Equal(v.Action.Kind, ActionCoup)
+	}
+
+	g.players[0].Coins = 3
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.Equal(g.LegalMoves(0), []Move{})
+	is.Equal(g.LegalMoves(1), []Move{{Input: InputPass}, {Input: InputChallenge}})
+
+	is.NoErr(g.ClaimPass())
+	moves = g.LegalMoves(0)
+	is.Equal(len(moves), 2)
+	is.NoErr(g.Action(*moves[0].Action))
+
+	is.Equal(g.LegalMoves(1), []Move{{Input: InputBlock, Character: CardContessa}, {Input: InputPass}})
+	is.NoErr(g.Claim(g.players[1], CardContessa))
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+
+	is.Equal(g.LegalMoves(1), []Move{
+		{Input: InputProve, Character: CardDuke},
+		{Input: InputProve, Character: CardContessa},
+	})
+
+	_, err := g.ClaimProve(CardDuke)
+	is.NoErr(err)
+
+	is.Equal(g.LegalMoves(0), []Move{})
+	is.Equal(g.LegalMoves(1), []Move{
+		{Input: InputLoseInfluence, Character: CardDuke, Place: 0},
+		{Input: InputLoseInfluence, Character: CardContessa, Place: 1},
+	})
+	is.NoErr(g.LoseInfluence(g.players[1], 1))
+
+	g, err = NewGame([5]*Player{{}, {}}, WithTwoPlayerVariant())
+	is.NoErr(err)
+
+	moves = g.LegalMoves(1)
+	is.Equal(len(moves), 5)
+	for k, character := range newDeck(g.characters(), 1) {
+		is.Equal(moves[k], Move{Input: InputSelectHand, Character: character})
+	}
+
+	g = newTestGame(t, Hand{CardDuke, CardCaptain}, Hand{CardDuke, CardContessa})
+	g.reformation, g.reserve = true, 1
+	g.players[0].Faction, g.players[1].Faction = FactionLoyalist, FactionReformist
+	g.players[0].Coins = 2
+
+	moves = g.LegalMoves(0)
+	is.Equal(moves[len(moves)-3:], []Move{
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionConvert}},
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionConvert, AgainstID: newUint8(1)}},
+		{Input: InputAction, Action: &Action{AuthorID: 0, Kind: ActionEmbezzle}},
+	})
+}
+
+// errNotClaimant is returned by play when a player other than the
+// claimant tries to prove the claim.
+var errNotClaimant = fmt.Errorf("player is not the claimant")
+
+// play submits the move of the player at index to the Game.
+func play(g *Game, index int, m Move) error {
+	p := g.players[index]
+	switch m.Input {
+	case InputAction, InputExchange, InputBlock:
+		if m.Action != nil {
+			return g.Action(*m.Action)
+		}
+
+		return g.Claim(p, m.Character)
+	case InputPass:
+		return g.Pass(p)
+	case InputChallenge:
+		return g.ClaimChallenge(p)
+	case InputProve:
+		if claimant, err := g.validateClaimAndItsPlayer(g.currentClaim()); err != nil || claimant != index {
+			return errNotClaimant
+		}
+
+		_, err := g.ClaimProve(m.Character)
+		return err
+	case InputLoseInfluence:
+		return g.LoseInfluence(p, m.Place)
+	case InputSelectHand:
+		return g.SelectHand(p, m.Character)
+	case InputShowCard:
+		return g.ShowCard(p, m.Place)
+	case InputExamine:
+		return g.Examine(p, m.Swap)
+	}
+
+	return fmt.Errorf("unknown input %d", m.Input)
+}
+
+// moveKey returns a comparable description of the move. The order of the
+// exchanged cards doesn't matter.
+func moveKey(m Move) string {
+	if m.Action == nil {
+		return fmt.Sprintf("%d %d %d %t", m.Input, m.Character, m.Place, m.Swap)
+	}
+
+	a := *m.Action
+	cards := append([]uint8{}, a.Cards...)
+	sort.Slice(cards, func(i, j int) bool { return cards[i] < cards[j] })
+
+	against := -1
+	if a.AgainstID != nil {
+		against = int(*a.AgainstID)
+	}
+
+	return fmt.Sprintf("%d %d %d %d %d %v", m.Input, a.AuthorID, a.Kind, a.Character, against, cards)
+}
+
+// candidateMoves returns every move the player at index could try to
+// submit to the Game; legal or not.
+func candidateMoves(g *Game, index int) []Move {
+	moves := []Move{}
+	phase := g.Phase()
+
+	claim, action := InputAction, InputAction
+	if phase == PhaseBlock {
+		claim = InputBlock
+	} else if phase == PhaseExchange {
+		action = InputExchange
+	}
+
+	targets := []*uint8{nil}
+	for k, v := range g.players {
+		if v != nil && k != index {
+			targets = append(targets, newUint8(uint8(k)))
+		}
+	}
+
+	for _, character := range g.characters() {
+		moves = append(moves,
+			Move{Input: claim, Character: character},
+			Move{Input: InputProve, Character: character},
+			Move{Input: InputSelectHand, Character: character},
+		)
+	}
+
+	for _, kind := range []uint8{ActionIncome, ActionFinancialAid, ActionCoup, ActionCharacter, ActionConvert, ActionEmbezzle} {
+		characters := []uint8{0}
+		if kind == ActionCharacter {
+			characters = g.characters()
+		}
+
+		for _, character := range characters {
+			for _, target := range targets {
+				a := Action{AuthorID: uint8(index), Kind: kind, Character: character, AgainstID: target}
+				moves = append(moves, Move{Input: action, Action: &a})
+
+				if phase != PhaseExchange || kind != ActionCharacter || target != nil {
+					continue
+				}
+
+				for k, first := range g.characters() {
+					keep := Action{AuthorID: uint8(index), Kind: kind, Character: character, Cards: []uint8{first}}
+					moves = append(moves, Move{Input: action, Action: &keep})
+
+					for _, second := range g.characters()[k:] {
+						keep := Action{AuthorID: uint8(index), Kind: kind, Character: character, Cards: []uint8{first, second}}
+						moves = append(moves, Move{Input: action, Action: &keep})
+					}
+				}
+			}
+		}
+	}
+
+	hand := g.players[index].Hand
+	for place := uint8(0); place < 2; place++ {
+		moves = append(moves,
+			Move{Input: InputLoseInfluence, Character: hand[place], Place: place},
+			Move{Input: InputShowCard, Character: hand[place], Place: place},
+		)
+	}
+
+	return append(moves,
+		Move{Input: InputPass},
+		Move{Input: InputChallenge},
+		Move{Input: InputExamine},
+		Move{Input: InputExamine, Swap: true},
+	)
+}
+
+// accepted returns the moves out of moves that the Game accepts from the
+// player at index. Every move is tried on a copy of the Game.
+func accepted(t *testing.T, g *Game, index int, moves []Move) map[string]Move {
+	s := g.Snapshot()
+
+	arr := map[string]Move{}
+	for _, m := range moves {
+		pl := make([]*Player, len(g.players))
+		for k, v := range g.players {
+			if v != nil {
+				pl[k] = &Player{}
+			}
+		}
+
+		r, err := Restore(s, pl)
+		if err != nil {
+			t.Fatalf("phase %d: %v", s.Phase, err)
+		}
+
+		if play(r, index, m) == nil {
+			arr[moveKey(m)] = m
+		}
+	}
+
+	return arr
+}
+
+// sortedKeys returns the keys of the moves in order.
+func sortedKeys(moves map[string]Move) []string {
+	arr := []string{}
+	for key := range moves {
+		arr = append(arr, key)
+	}
+	sort.Strings(arr)
+
+	return arr
+}
+
+// TestGameLegalMovesAgree plays random games through LegalMoves and
+// makes sure that the Game accepts exactly the moves LegalMoves returns.
+func TestGameLegalMovesAgree(t *testing.T) {
+	configs := []struct {
+		name string
+		opts []Option
+	}{
+		{"classic", nil},
+		{"reformation", []Option{WithReformation(), WithInquisitor()}},
+		{"variant", []Option{WithTwoPlayerVariant()}},
+	}
+
+	for _, config := range configs {
+		name, opts := config.name, config.opts
+		for seed := int64(1); seed <= 4; seed++ {
+			pl := [5]*Player{{}, {}, {}, {}}
+			if name == "variant" {
+				pl = [5]*Player{{}, {}}
+			}
+
+			g, err := NewGame(pl, append([]Option{WithSeed(seed)}, opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := rand.New(rand.NewSource(seed))
+			for step := 0; step < 300 && !g.IsOver(); step++ {
+				decisions := g.Pending().Decisions
+				for index, v := range g.players {
+					if v == nil {
+						continue
+					}
+
+					legal := map[string]Move{}
+					for _, m := range g.LegalMoves(index) {
+						legal[moveKey(m)] = m
+					}
+
+					got := accepted(t, g, index, append(candidateMoves(g, index), g.LegalMoves(index)...))
+					for _, key := range sortedKeys(got) {
+						if _, ok := legal[key]; !ok {
+							t.Fatalf("%s/%d: phase %d accepts an illegal move of %d: %s", name, seed, g.Phase(), index, key)
+						}
+					}
+					for _, key := range sortedKeys(legal) {
+						if _, ok := got[key]; !ok {
+							t.Fatalf("%s/%d: phase %d rejects a legal move of %d: %s", name, seed, g.Phase(), index, key)
+						}
+					}
+				}
+
+				if len(decisions) == 0 {
+					if g.Phase() == PhaseTurnEnd {
+						g.NextTurn()
+					} else if err := g.DoAction(); err != nil {
+						t.Fatalf("%s/%d: %v", name, seed, err)
+					}
+
+					continue
+				}
+
+				d := decisions[r.Intn(len(decisions))]
+				moves := g.LegalMoves(int(d.Player))
+				if err := play(g, int(d.Player), moves[r.Intn(len(moves))]); err != nil {
+					t.Fatalf("%s/%d: %v", name, seed, err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Option is a function that configures a Game before NewGame deals the
+// cards. Options are applied in the order they were given.
+type Option func(g *Game)
+
+// WithSeed makes the Game shuffle its deck with a random source seeded
+// with seed. Two games created with the same seed and the same players
+// are dealt the exact same cards, which makes them replayable.
+func WithSeed(seed int64) Option {
+	return func(g *Game) {
+		g.seed, g.seeded = seed, true
+		g.source = &countingSource{src: rand.NewSource(seed)}
+		g.rand = rand.New(g.source)
+	}
+}
+
+// WithSource makes the Game shuffle its deck with src. Since the seed of
+// src isn't known, Game.Seed reports that the Game cannot be replayed.
+func WithSource(src rand.Source) Option {
+	return func(g *Game) {
+		g.seed, g.seeded = 0, false
+		g.source = &countingSource{src: src}
+		g.rand = rand.New(g.source)
+	}
+}
+
+// WithTreasury sets the amount of coins the treasury holds before the
+// starting coins are dealt. By default, the treasury holds 50 coins.
+func WithTreasury(coins uint8) Option {
+	return func(g *Game) {
+		g.treasury = coins
+	}
+}
+
+// WithTwoPlayerVariant makes the Game follow the official rules of the
+// two-player variant. Instead of being dealt two random cards, both
+// players are dealt one of every character and pick the one they keep;
+// the rest is shuffled into the deck and both players draw their second
+// card from it. The starting player only gets 1 coin.
+//
+// Do note: NewGame returns ErrInvalidVariant unless exactly 2 players are
+//          seated.
+func WithTwoPlayerVariant() Option {
+	return func(g *Game) {
+		g.variant = true
+	}
+}
+
+// WithReformation makes the Game follow the rules of the Reformation
+// expansion. Every player belongs to a faction; starting with the first
+// seated player, they alternate between FactionLoyalist and
+// FactionReformist. Players cannot target, or block, players of their
+// own faction unless every living player shares one. ActionConvert and
+// ActionEmbezzle are only available with the expansion.
+func WithReformation() Option {
+	return func(g *Game) {
+		g.reformation = true
+	}
+}
+
+// WithInquisitor replaces every CardAmbassador of the deck with a
+// CardInquisitor. Claims of a character that isn't in the deck fail with
+// ErrInvalidCharacter.
+func WithInquisitor() Option {
+	return func(g *Game) {
+		roster := []uint8{}
+		for _, v := range g.roster {
+			if v != CardAmbassador {
+				roster = append(roster, v)
+			}
+		}
+
+		g.roster = roster
+		g.addCharacters(CardInquisitor)
+	}
+}
+
+// WithCharacters adds the house-rule characters to the deck of the Game,
+// alongside the classic ones. Every character must have been registered
+// beforehand, otherwise NewGame returns ErrInvalidCharacter. See
+// RegisterCharacter.
+func WithCharacters(characters ...uint8) Option {
+	return func(g *Game) {
+		g.addCharacters(characters...)
+	}
+}
+
+// addCharacters adds the characters that aren't in play yet to the
+// roster of the Game, which is kept in order.
+func (g *Game) addCharacters(characters ...uint8) {
+	for _, character := range characters {
+		if !g.inPlay(character) {
+			g.roster = append(g.roster, character)
+		}
+	}
+
+	sort.Slice(g.roster, func(i, j int) bool { return g.roster[i] < g.roster[j] })
+}
+
+// WithRules makes the Game follow the RuleSet instead of DefaultRules.
+// See LoadRules.
+func WithRules(r RuleSet) Option {
+	return func(g *Game) {
+		g.rules = r
+	}
+}
+
+// defaultOptions returns the options applied to every Game before the
+// options given to NewGame.
+func defaultOptions() []Option {
+	return []Option{WithSeed(time.Now().UnixNano()), WithTreasury(defaultTreasury), WithRules(DefaultRules())}
+}
+
+// Seed returns the seed that the Game's random source was created with.
+// The second return value is false if the Game was created with
+// WithSource.
+func (g *Game) Seed() (int64, bool) {
+	g.deckMtx.Lock()
+	defer g.deckMtx.Unlock()
+
+	return g.seed, g.seeded
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWithSeed(t *testing.T) {
+	g := &Game{}
+	WithSeed(5)(g)
+
+	is := is.New(t)
+
+	seed, ok := g.Seed()
+	is.True(ok)
+	is.Equal(seed, int64(5))
+	is.Equal(g.rand.Int63(), rand.New(rand.NewSource(5)).Int63())
+}
+
+func TestWithSource(t *testing.T) {
+	g := &Game{}
+	WithSeed(5)(g)
+	WithSource(rand.NewSource(6))(g)
+
+	is := is.New(t)
+
+	_, ok := g.Seed()
+	is.True(!ok)
+	is.Equal(g.rand.Int63(), rand.New(rand.NewSource(6)).Int63())
+}
+
+func TestWithTreasury(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{}
+	WithTreasury(20)(g)
+	is.Equal(g.Treasury(), uint8(20))
+
+	g, err := NewGame([5]*Player{{}, {}, {}})
+	is.NoErr(err)
+	is.Equal(g.Treasury(), defaultTreasury-3*DefaultRules().StartingCoins)
+
+	g, err = NewGame([5]*Player{{}, {}}, WithTreasury(4))
+	is.NoErr(err)
+	is.Equal(g.Treasury(), uint8(0))
+
+	_, err = NewGame([5]*Player{{}, {}}, WithTreasury(3))
+	is.Equal(err, ErrInsufficientTreasury)
+}
+
+func TestWithTwoPlayerVariant(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{}
+	WithTwoPlayerVariant()(g)
+	is.True(g.variant)
+}
+
+func TestWithReformation(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{}
+	WithReformation()(g)
+	is.True(g.reformation)
+}
+
+func TestWithInquisitor(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{{}, {}}
+	g, err := NewGame(pl, WithInquisitor())
+	is.NoErr(err)
+	is.True(g.inPlay(CardInquisitor))
+
+	for _, v := range append(append([]uint8{}, g.deck...), pl[0].Hand[0], pl[0].Hand[1], pl[1].Hand[0], pl[1].Hand[1]) {
+		is.True(v != CardAmbassador)
+	}
+}
+
+func TestNewGameSeed(t *testing.T) {
+	is := is.New(t)
+
+	first, err := NewGame([5]*Player{{}, {}, {}}, WithSeed(42))
+	is.NoErr(err)
+	second, err := NewGame([5]*Player{{}, {}, {}}, WithSeed(42))
+	is.NoErr(err)
+
+	is.Equal(first.deck, second.deck)
+	is.Equal(len(first.history), len(second.history))
+	for k, v := range first.history {
+		is.True(sameEntry(v, second.history[k]))
+	}
+	for k := range first.players[:first.max] {
+		is.Equal(first.players[k].Hand, second.players[k].Hand)
+	}
+
+	first.Shuffle()
+	second.Shuffle()
+	is.Equal(first.deck, second.deck)
+
+	g, err := NewGame([5]*Player{{}, {}})
+	is.NoErr(err)
+
+	_, ok := g.Seed()
+	is.True(ok)
+}
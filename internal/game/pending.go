@@ -0,0 +1,177 @@
+package game
+
+import "time"
+
+const (
+	// InputAction lets the player either Claim a character or set the
+	// primary Action of the turn. See Game.Claim and Game.Action.
+	InputAction uint8 = iota + 1
+	// InputPass lets the player pass during a reaction window. See
+	// Game.Pass.
+	InputPass
+	// InputChallenge lets the player challenge a Claim. See
+	// Game.ClaimChallenge.
+	InputChallenge
+	// InputBlock lets the player counter the primary Action by claiming
+	// one of Decision.Characters.
+	InputBlock
+	// InputProve lets the player prove their challenged Claim. See
+	// Game.ClaimProve.
+	InputProve
+	// InputLoseInfluence lets the player choose which of their cards
+	// they lose. See Game.LoseInfluence.
+	InputLoseInfluence
+	// InputExchange lets the player choose which cards they keep once the
+	// cards of their exchange have been drawn; two for an Ambassador and
+	// one for an Inquisitor. See Game.ExchangePool.
+	InputExchange
+	// InputSelectHand lets the player pick the card they keep out of the
+	// set they were dealt in the two-player variant. See Game.SelectHand.
+	InputSelectHand
+	// InputShowCard lets the target of an Inquisitor choose which card
+	// they show. See Game.ShowCard.
+	InputShowCard
+	// InputExamine lets the Inquisitor decide whether the shown card is
+	// swapped with the deck. See Game.Examine.
+	InputExamine
+)
+
+// Decision is a structure that describes what a single player is allowed
+// to submit to the Game.
+type Decision struct {
+	Player uint8   `json:"player"`
+	Inputs []uint8 `json:"inputs"`
+	// Characters is only set alongside InputBlock. It holds the characters
+	// the player could block with.
+	Characters []uint8 `json:"characters,omitempty"`
+}
+
+// Pending is a structure that describes what the Game is currently
+// waiting for.
+//
+// An empty slice of Decisions means that the Game isn't waiting on any
+// player. Instead, it waits for Game.DoAction or Game.NextTurn depending
+// on Phase.
+type Pending struct {
+	Phase     uint8      `json:"phase"`
+	Decisions []Decision `json:"decisions"`
+	// Deadline is only set if the Game has a timeout and at-least one
+	// player is expected to act. See Game.SetTimeout.
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// Players returns the indexes of every player expected to act.
+func (p Pending) Players() []uint8 {
+	arr := []uint8{}
+	for _, v := range p.Decisions {
+		arr = append(arr, v.Player)
+	}
+
+	return arr
+}
+
+// SetTimeout sets how long players have to act once a phase has started.
+// The timeout is only used to compute Pending.Deadline; Game doesn't
+// enforce it by itself.
+//
+// A zero duration disables deadlines.
+func (g *Game) SetTimeout(d time.Duration) {
+	g.phaseMtx.Lock()
+	g.timeout = d
+	g.phaseMtx.Unlock()
+}
+
+// livingPlayers returns the indexes of every living player except the
+// one at index except. A negative except excludes no one.
+func (g *Game) livingPlayers(except int) []uint8 {
+	arr := []uint8{}
+	for k, v := range g.players[:g.max] {
+		if k != except && v != nil && !v.IsDead() {
+			arr = append(arr, uint8(k))
+		}
+	}
+
+	return arr
+}
+
+// Pending returns the players the Game is waiting on and what each of
+// them can submit. It is derived from the current phase, claim and
+// actions of the Game.
+func (g *Game) Pending() Pending {
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	return g.pending()
+}
+
+// pending is the underlying function of Game.Pending. Players who have
+// passed during the current reaction window are left out.
+//
+// pending must be called while holding stackMtx.
+func (g *Game) pending() Pending {
+	g.phaseMtx.Lock()
+	p := Pending{Phase: g.phase, Decisions: []Decision{}}
+	start, timeout := g.phaseStart, g.timeout
+	g.phaseMtx.Unlock()
+
+	claimant := -1
+	if c := g.currentClaim(); c != nil {
+		claimant, _ = g.validateClaimAndItsPlayer(c)
+	}
+
+	add := func(index int, inputs ...uint8) {
+		if index >= 0 {
+			p.Decisions = append(p.Decisions, Decision{Player: uint8(index), Inputs: inputs})
+		}
+	}
+
+	switch p.Phase {
+	case PhaseAction:
+		turn, err := g.TurnGet()
+		if err == nil {
+			add(turn, InputAction)
+		}
+	case PhaseExchange:
+		add(claimant, InputExchange)
+	case PhaseHandSelection:
+		for k := range g.players {
+			if g.selectionFor(k) != nil {
+				add(k, InputSelectHand)
+			}
+		}
+	case PhaseReaction, PhaseBlockChallenge:
+		for _, v := range g.livingPlayers(claimant) {
+			if !g.hasPassed(v) {
+				add(int(v), InputPass, InputChallenge)
+			}
+		}
+	case PhaseBlock:
+		primary := *g.primaryAction()
+		for _, v := range g.livingPlayers(int(primary.AuthorID)) {
+			if g.hasPassed(v) || !isValidBlocker(primary, v) || !g.mayTarget(v, primary.AuthorID) {
+				continue
+			}
+
+			p.Decisions = append(p.Decisions, Decision{
+				Player:     v,
+				Inputs:     []uint8{InputBlock, InputPass},
+				Characters: g.playable(counterCharacters(primary)),
+			})
+		}
+	case PhaseProof:
+		add(claimant, InputProve)
+	case PhaseInfluenceLoss:
+		add(findPlayerByPntr(g.players, g.loss.victim), InputLoseInfluence)
+	case PhaseShowCard:
+		add(findPlayerByPntr(g.players, g.examination.target), InputShowCard)
+	case PhaseExamine:
+		add(findPlayerByPntr(g.players, g.examination.inquisitor), InputExamine)
+	}
+
+	if len(p.Decisions) > 0 && timeout > 0 {
+		deadline := start.Add(timeout)
+		p.Deadline = &deadline
+	}
+
+	return p
+}
@@ -0,0 +1,87 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPendingPlayers(t *testing.T) {
+	is := is.New(t)
+
+	p := Pending{Decisions: []Decision{{Player: 2}, {Player: 0}}}
+	is.Equal(p.Players(), []uint8{2, 0})
+	is.Equal(Pending{}.Players(), []uint8{})
+}
+
+func TestGameLivingPlayers(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke}, Hand{}, Hand{CardDuke})
+
+	is := is.New(t)
+
+	is.Equal(g.livingPlayers(-1), []uint8{0, 2})
+	is.Equal(g.livingPlayers(0), []uint8{2})
+}
+
+func TestGamePending(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	p := g.Pending()
+	is.Equal(p.Phase, PhaseAction)
+	is.Equal(p.Decisions, []Decision{{Player: 0, Inputs: []uint8{InputAction}}})
+	is.Equal(p.Deadline, nil)
+
+	g.SetTimeout(time.Minute)
+
+	g.players[0].Coins = g.rules.AssassinCost
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	p = g.Pending()
+	is.Equal(p.Decisions, []Decision{
+		{Player: 1, Inputs: []uint8{InputPass, InputChallenge}},
+		{Player: 2, Inputs: []uint8{InputPass, InputChallenge}},
+	})
+	is.True(p.Deadline != nil)
+	is.Equal(*p.Deadline, g.phaseStart.Add(time.Minute))
+
+	is.NoErr(g.ClaimPass())
+
+	one := uint8(1)
+	g.players[0].Coins = 3
+	is.NoErr(g.Action(Action{AuthorID: 0, AgainstID: &one, Kind: ActionCharacter, Character: CardAssassin}))
+
+	p = g.Pending()
+	is.Equal(p.Phase, PhaseBlock)
+	is.Equal(p.Decisions, []Decision{
+		{Player: 1, Inputs: []uint8{InputBlock, InputPass}, Characters: []uint8{CardContessa}},
+	})
+
+	is.NoErr(g.Claim(g.players[1], CardContessa))
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+
+	p = g.Pending()
+	is.Equal(p.Decisions, []Decision{{Player: 1, Inputs: []uint8{InputProve}}})
+
+	_, err := g.ClaimProve(CardContessa)
+	is.NoErr(err)
+
+	p = g.Pending()
+	is.Equal(p.Decisions, []Decision{{Player: 0, Inputs: []uint8{InputLoseInfluence}}})
+
+	is.NoErr(g.LoseInfluence(g.players[0], 0))
+
+	p = g.Pending()
+	is.Equal(p.Phase, PhaseResolve)
+	is.Equal(p.Decisions, []Decision{})
+	is.Equal(p.Deadline, nil)
+
+	g, err = NewGame([5]*Player{{}, {}}, WithTwoPlayerVariant())
+	is.NoErr(err)
+	is.NoErr(g.SelectHand(g.players[0], CardDuke))
+
+	p = g.Pending()
+	is.Equal(p.Phase, PhaseHandSelection)
+	is.Equal(p.Decisions, []Decision{{Player: 1, Inputs: []uint8{InputSelectHand}}})
+}
@@ -0,0 +1,100 @@
+package game
+
+import "time"
+
+const (
+	// PhaseAction waits for the player whose turn it is to either Claim
+	// a character or to set a primary Action. Once a character's Claim
+	// has passed, the Game goes back to PhaseAction waiting for the
+	// character's Action.
+	PhaseAction uint8 = iota
+	// PhaseReaction waits for the primary Claim of the turn to be either
+	// passed via Game.ClaimPass or challenged via Game.ClaimChallenge.
+	PhaseReaction
+	// PhaseBlock waits for a counter Claim that blocks the primary Action.
+	// If no one blocks, Game.DoAction executes the primary Action.
+	PhaseBlock
+	// PhaseBlockChallenge is the same as PhaseReaction but for the counter
+	// Claim made during PhaseBlock.
+	PhaseBlockChallenge
+	// PhaseProof waits for the challenged player to call Game.ClaimProve.
+	PhaseProof
+	// PhaseInfluenceLoss waits for the victim of a Coup, of an
+	// assassination or of a lost challenge to choose which card they
+	// lose. See Game.LoseInfluence.
+	PhaseInfluenceLoss
+	// PhaseExchange waits for the cards kept by the Ambassador or the
+	// Inquisitor. The Ambassador draws two cards once its Claim has
+	// passed; the Inquisitor draws one once it submits its Action without
+	// a target. See Game.ExchangePool.
+	PhaseExchange
+	// PhaseResolve waits for Game.DoAction to execute an Action that
+	// cannot be blocked anymore.
+	PhaseResolve
+	// PhaseTurnEnd waits for Game.NextTurn.
+	PhaseTurnEnd
+	// PhaseGameOver is the last phase of a Game. Nothing can happen after
+	// it.
+	PhaseGameOver
+	// PhaseHandSelection is the first phase of a two-player variant Game.
+	// It waits for both players to pick the card they keep out of the set
+	// they were dealt. See Game.SelectHand.
+	PhaseHandSelection
+	// PhaseShowCard waits for the target of an Inquisitor to choose which
+	// card they show. See Game.ShowCard.
+	PhaseShowCard
+	// PhaseExamine waits for the Inquisitor to decide whether the shown
+	// card is swapped with the deck. See Game.Examine.
+	PhaseExamine
+)
+
+// IsValidPhase returns true if the value is in between PhaseAction &&
+// PhaseExamine.
+func IsValidPhase(v uint8) bool {
+	return v <= PhaseExamine
+}
+
+// counterCharacters returns every character that can counter the Action.
+// For targeted Actions, these are the characters its target could block
+// with.
+func counterCharacters(a Action) []uint8 {
+	counter := Action{Kind: ActionCharacter}
+	if a.AgainstID != nil {
+		counter.AuthorID = *a.AgainstID
+	}
+
+	arr := []uint8{}
+	for _, character := range characterIDs() {
+		counter.Character = character
+		if IsValidCounterAction(a, counter) {
+			arr = append(arr, character)
+		}
+	}
+
+	return arr
+}
+
+// isBlockable returns true if any character of the Game's deck can
+// counter the Action.
+func (g *Game) isBlockable(a Action) bool {
+	return len(g.playable(counterCharacters(a))) > 0
+}
+
+// Phase returns the current phase of the Game. See PhaseAction.
+func (g *Game) Phase() uint8 {
+	g.phaseMtx.Lock()
+	defer g.phaseMtx.Unlock()
+
+	return g.phase
+}
+
+// setPhase sets the current phase of the Game.
+//
+// setPhase also records the time at which the phase started; see
+// Game.Pending.
+func (g *Game) setPhase(phase uint8) {
+	g.phaseMtx.Lock()
+	g.phase = phase
+	g.phaseStart = time.Now()
+	g.phaseMtx.Unlock()
+}
@@ -0,0 +1,125 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestIsValidPhase(t *testing.T) {
+	is := is.New(t)
+	for i := uint8(0); i < ^uint8(0); i++ {
+		is.Equal(IsValidPhase(i), i <= PhaseExamine)
+	}
+}
+
+func TestGameIsBlockable(t *testing.T) {
+	is := is.New(t)
+
+	g := &Game{roster: classicRoster()}
+
+	is.True(g.isBlockable(Action{Kind: ActionFinancialAid}))
+	is.True(g.isBlockable(Action{Kind: ActionCharacter, Character: CardAssassin, AgainstID: newUint8(1)}))
+	is.True(g.isBlockable(Action{Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(1)}))
+
+	is.True(!g.isBlockable(Action{Kind: ActionIncome}))
+	is.True(!g.isBlockable(Action{Kind: ActionCoup}))
+	is.True(!g.isBlockable(Action{Kind: ActionCharacter, Character: CardDuke}))
+	is.True(!g.isBlockable(Action{Kind: ActionCharacter, Character: CardAmbassador}))
+}
+
+func TestGamePhase(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+	is.Equal(g.Phase(), PhaseAction)
+
+	one := uint8(1)
+	g.players[1].Coins = 2
+
+	// a captain steals; the block is challenged and fails.
+	is.NoErr(g.Claim(g.players[0], CardCaptain))
+	is.Equal(g.Phase(), PhaseReaction)
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseAction)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, AgainstID: &one, Kind: ActionCharacter, Character: CardCaptain}))
+	is.Equal(g.Phase(), PhaseBlock)
+
+	is.Equal(g.Claim(g.players[1], CardDuke), ErrInvalidCounterClaim)
+	is.Equal(g.Claim(g.players[0], CardCaptain), ErrInvalidActionSamePlayer)
+	is.NoErr(g.Claim(g.players[1], CardAmbassador))
+	is.Equal(g.Phase(), PhaseBlockChallenge)
+
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+	is.Equal(g.Phase(), PhaseProof)
+
+	succeed, err := g.ClaimProve(CardDuke)
+	is.NoErr(err)
+	is.True(!succeed)
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+	is.NoErr(g.LoseInfluence(g.players[1], 0))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.Equal(g.players[1].Hand, Hand{CardDuke | cardRevealed, CardContessa})
+
+	is.NoErr(g.DoAction())
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(g.players[0].Coins, uint8(2))
+	is.Equal(g.players[1].Coins, uint8(0))
+	is.Equal(g.stack, nil)
+
+	g.NextTurn()
+	is.Equal(g.Phase(), PhaseAction)
+
+	// foreign aid is blocked by a duke
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionFinancialAid}))
+	is.Equal(g.Phase(), PhaseBlock)
+	is.NoErr(g.Claim(g.players[0], CardDuke))
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[1].Coins, uint8(0))
+	is.Equal(g.players[0].Coins, uint8(2))
+	is.Equal(g.Phase(), PhaseTurnEnd)
+
+	g.NextTurn()
+
+	// an ambassador's claim waits for the exchange
+	is.NoErr(g.Claim(g.players[0], CardAmbassador))
+	is.NoErr(g.ClaimPass())
+	is.Equal(g.Phase(), PhaseExchange)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionIncome}), ErrInvalidCharacter)
+	pool := g.ExchangePool(0)
+	is.Equal(len(pool), 4)
+	is.Equal(g.ExchangePool(1), nil)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador}), ErrInvalidExchange)
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador, Cards: pool[2:]}))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Hand, Hand{pool[2], pool[3]})
+
+	g.NextTurn()
+
+	// a failed primary claim ends the turn
+	g.players[1].Coins = g.rules.AssassinCost
+	is.NoErr(g.Claim(g.players[1], CardAssassin))
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+	// the last card is lost without a choice
+	_, err = g.ClaimProve(CardContessa)
+	is.NoErr(err)
+
+	is.Equal(g.Phase(), PhaseGameOver)
+	is.Equal(g.Winner(), 0)
+}
+
+func TestCounterCharacters(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(counterCharacters(Action{Kind: ActionFinancialAid}), []uint8{CardDuke})
+	is.Equal(counterCharacters(Action{Kind: ActionCharacter, Character: CardAssassin, AgainstID: newUint8(1)}), []uint8{CardContessa})
+	is.Equal(counterCharacters(Action{Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(0)}), []uint8{CardAmbassador, CardCaptain, CardInquisitor})
+	is.Equal(counterCharacters(Action{Kind: ActionCharacter, Character: CardCaptain}), []uint8{})
+	is.Equal(counterCharacters(Action{Kind: ActionIncome}), []uint8{})
+}
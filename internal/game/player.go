@@ -2,10 +2,14 @@ package game
 
 // Player is a structure representing a player. A player consists of
 // two things: a Hand(collection of cards) and a Coin balance.
+//
+// With the Reformation expansion, a player also belongs to a Faction. See
+// FactionLoyalist.
 type Player struct {
-	dead  bool
-	Coins uint8
-	Hand  Hand
+	dead    bool
+	Coins   uint8
+	Hand    Hand
+	Faction uint8
 }
 
 // IsDead returns true if the player has an empty hand.
@@ -17,4 +17,10 @@ func TestPlayerIsDead(t *testing.T) {
 	p.Hand[0] = CardAssassin
 	
 	is.True(!p.IsDead())
-}
\ No newline at end of file
+
+	p.Hand[1] = CardDuke | cardRevealed
+	is.True(!p.IsDead())
+
+	p.Hand[0] |= cardRevealed
+	is.True(p.IsDead())
+}
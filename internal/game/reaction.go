@@ -0,0 +1,100 @@
+package game
+
+// openWindow moves the Game to a phase that opens a reaction window;
+// either PhaseReaction, PhaseBlock or PhaseBlockChallenge. Every passes
+// of the previous window are forgotten.
+//
+// openWindow must be called while holding stackMtx.
+func (g *Game) openWindow(phase uint8) {
+	g.passed = nil
+	g.setPhase(phase)
+}
+
+// hasPassed returns true if the player at index has passed during the
+// current reaction window.
+//
+// hasPassed must be called while holding stackMtx.
+func (g *Game) hasPassed(index uint8) bool {
+	for _, v := range g.passed {
+		if v == index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Pass lets a single player pass during a reaction window. Once every
+// eligible player has passed, the window closes: a claim passes like it
+// would through Game.ClaimPass, and an unblocked Action moves the Game
+// to PhaseResolve. Every pass is stored in the history as an
+// ActionReactionPass.
+//
+// The eligible players are the ones reported by Game.Pending. A player who
+// has already passed cannot pass, challenge or block again during the
+// same window; ErrAlreadyPassed is returned. Any other player gets
+// ErrInvalidReactor.
+//
+// Reactions are arbitrated on a first-come basis: Pass, Game.ClaimChallenge
+// and counter claims through Game.Claim are applied one at a time in the
+// order they acquire the Game. The first challenge or block closes the
+// window, and every later reaction to the same window fails.
+func (g *Game) Pass(player *Player) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	phase := g.Phase()
+	if phase != PhaseReaction && phase != PhaseBlock && phase != PhaseBlockChallenge {
+		return ErrInvalidPhase
+	}
+
+	index := findPlayerByPntr(g.players, player)
+	if index < 0 {
+		return ErrInvalidReactor
+	} else if g.hasPassed(uint8(index)) {
+		return ErrAlreadyPassed
+	}
+
+	eligible := false
+	for _, v := range g.pending().Players() {
+		eligible = eligible || int(v) == index
+	}
+
+	if !eligible {
+		return ErrInvalidReactor
+	}
+
+	// a pass responds to the Action or the claim it lets through.
+	var against uint8
+	var cause uint64
+	if phase == PhaseBlock {
+		against, cause = g.primaryAction().AuthorID, *g.stack[0].seq
+	} else {
+		c := g.currentClaim()
+		claimant, _ := g.validateClaimAndItsPlayer(c)
+		against, cause = uint8(claimant), c.seq
+	}
+
+	g.passed = append(g.passed, uint8(index))
+	g.addResponseToHistory(Action{
+		AuthorID:  uint8(index),
+		Kind:      ActionReactionPass,
+		AgainstID: &against,
+	}, cause)
+
+	if len(g.pending().Decisions) > 0 {
+		return nil
+	}
+
+	if phase == PhaseBlock {
+		g.setPhase(PhaseResolve)
+	} else {
+		g.passClaim(g.currentClaim())
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameHasPassed(t *testing.T) {
+	is := is.New(t)
+	g := &Game{}
+
+	is.True(!g.hasPassed(1))
+	g.passed = []uint8{2, 1}
+	is.True(g.hasPassed(1))
+	is.True(!g.hasPassed(0))
+
+	g.openWindow(PhaseBlock)
+	is.True(!g.hasPassed(1))
+	is.Equal(g.Phase(), PhaseBlock)
+}
+
+func TestGamePass(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	is.Equal(g.Pass(g.players[1]), ErrInvalidPhase)
+
+	is.NoErr(g.Claim(g.players[0], CardDuke))
+	is.Equal(g.Pass(g.players[0]), ErrInvalidReactor)
+	is.Equal(g.Pass(&Player{}), ErrInvalidReactor)
+
+	is.NoErr(g.Pass(g.players[1]))
+	is.Equal(g.history[len(g.history)-1].Action, Action{AuthorID: 1, Kind: ActionReactionPass, AgainstID: newUint8(0)})
+	is.Equal(g.Pass(g.players[1]), ErrAlreadyPassed)
+	is.Equal(g.ClaimChallenge(g.players[1]), ErrAlreadyPassed)
+	is.Equal(g.Pending().Players(), []uint8{2})
+	is.Equal(g.Phase(), PhaseReaction)
+
+	// the window closes once everyone has passed
+	is.NoErr(g.Pass(g.players[2]))
+	is.Equal(g.Phase(), PhaseAction)
+	is.True(g.currentClaim().succeed != nil)
+	is.Equal(g.history[len(g.history)-1].Kind, ActionClaimPassed)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardDuke}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	// a new window forgets the previous passes
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionFinancialAid}))
+	is.Equal(g.Phase(), PhaseBlock)
+	is.Equal(g.Pending().Players(), []uint8{0, 2})
+
+	is.NoErr(g.Pass(g.players[2]))
+	is.Equal(g.Claim(g.players[2], CardDuke), ErrAlreadyPassed)
+	is.NoErr(g.Pass(g.players[0]))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.Equal(g.Pass(g.players[0]), ErrInvalidPhase)
+
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[1].Coins, uint8(2))
+}
+
+func TestGamePassFirstReaction(t *testing.T) {
+	g := newTestGame(t, Hand{CardAmbassador, CardAssassin}, Hand{CardDuke, CardContessa}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionFinancialAid}))
+	is.NoErr(g.Pass(g.players[1]))
+
+	// the first block closes the window; later reactions fail
+	is.NoErr(g.Claim(g.players[2], CardDuke))
+	is.Equal(g.Phase(), PhaseBlockChallenge)
+	is.Equal(g.Claim(g.players[1], CardDuke), ErrInvalidPhase)
+
+	// the block opens a new window where everyone may react again
+	is.Equal(g.Pending().Players(), []uint8{0, 1})
+	is.NoErr(g.Pass(g.players[1]))
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+	is.Equal(g.Pass(g.players[0]), ErrInvalidPhase)
+	is.Equal(g.ClaimChallenge(g.players[1]), ErrInvalidClaimFinished)
+}
@@ -0,0 +1,99 @@
+package game
+
+const (
+	// FactionNone is the faction of every player outside of the
+	// Reformation expansion.
+	FactionNone uint8 = iota
+	// FactionLoyalist is the faction of the first seated player of the
+	// Reformation expansion. See WithReformation.
+	FactionLoyalist
+	// FactionReformist is the faction of the second seated player of the
+	// Reformation expansion. See WithReformation.
+	FactionReformist
+)
+
+// assignFactions gives every seated player their starting faction;
+// alternating between FactionLoyalist and FactionReformist from the seat
+// at first onwards. Outside of the Reformation expansion, every player
+// is given FactionNone.
+func (g *Game) assignFactions(first int) {
+	faction := FactionLoyalist
+	for k := first; k < g.max; k++ {
+		v := g.players[k]
+		if v == nil {
+			continue
+		}
+
+		v.Faction = FactionNone
+		if g.reformation {
+			v.Faction = faction
+			faction = ConvertAction(faction)
+		}
+	}
+}
+
+// mayTarget returns true if the player at author may target, or block,
+// the player at target. Outside of the Reformation expansion, or once
+// every living player shares one faction, anyone may be targeted.
+// Otherwise, only the players of the other faction may.
+func (g *Game) mayTarget(author, target uint8) bool {
+	faction := g.players[author].Faction
+	if !g.reformation || g.players[target].Faction != faction {
+		return true
+	}
+
+	for _, v := range g.players {
+		if v != nil && !v.IsDead() && v.Faction != faction {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Reserve returns the amount of coins in the Treasury Reserve of the
+// Reformation expansion. Conversions are paid to the reserve and an
+// Embezzlement takes all of it. See ActionConvert and ActionEmbezzle.
+func (g *Game) Reserve() uint8 {
+	g.treasuryMtx.Lock()
+	defer g.treasuryMtx.Unlock()
+
+	return g.reserve
+}
+
+// proveInverted reveals the live cards of the author of an inverted
+// claim. The claim holds up if none of them is the claim's character; the
+// revealed cards are then replaced. See Game.takeCards. The proof is
+// stored in the history as an ActionClaimProof holding the revealed
+// cards. Either way, the punished player must lose an influence.
+//
+// proveInverted must be called while holding stackMtx.
+func (g *Game) proveInverted(c *claim) {
+	index, _ := g.validateClaimAndItsPlayer(c)
+	challenger := uint8(findPlayerByPntr(g.players, c.challenger))
+
+	held, revealed := true, []uint8{}
+	places := c.author.Hand.places()
+	for _, place := range places {
+		revealed = append(revealed, c.author.Hand[place])
+		held = held && c.author.Hand[place] != c.character
+	}
+
+	c.proofSeq = g.addResponseToHistory(Action{
+		AuthorID:  uint8(index),
+		AgainstID: &challenger,
+		Kind:      ActionClaimProof,
+		Character: c.character,
+		Cards:     revealed,
+		Inverted:  true,
+	}, c.challengeSeq)
+
+	c.Prove(held)
+	if held {
+		g.takeCards(c.author, places, c.proofSeq)
+	}
+
+	_, loser := c.challengeOutcome()
+	g.setLoss(loser, true, c.proofSeq)
+	g.updateEliminations()
+}
@@ -0,0 +1,129 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameAssignFactions(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{nil, {}, {}, nil, {}}
+	_, err := NewGame(pl, WithReformation())
+	is.NoErr(err)
+
+	is.Equal(pl[1].Faction, FactionLoyalist)
+	is.Equal(pl[2].Faction, FactionReformist)
+	is.Equal(pl[4].Faction, FactionLoyalist)
+
+	_, err = NewGame(pl)
+	is.NoErr(err)
+
+	for _, index := range []int{1, 2, 4} {
+		is.Equal(pl[index].Faction, FactionNone)
+	}
+}
+
+func TestGameMayTarget(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke, CardCaptain}, Hand{CardDuke, CardContessa}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	// anyone may be targeted outside of the expansion
+	is.True(g.mayTarget(0, 1))
+
+	g.reformation = true
+	g.players[0].Faction, g.players[1].Faction, g.players[2].Faction = FactionLoyalist, FactionLoyalist, FactionReformist
+
+	is.True(!g.mayTarget(0, 1))
+	is.True(g.mayTarget(0, 2))
+	is.True(g.mayTarget(2, 1))
+
+	g.players[0].Coins = 7
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}), ErrSameFaction)
+	is.Equal(g.LegalMoves(0)[2].Action.AgainstID, newUint8(2))
+
+	// only the other faction may block foreign aid
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionFinancialAid}))
+	is.Equal(g.Phase(), PhaseBlock)
+	is.Equal(g.Pending().Players(), []uint8{2})
+	is.Equal(g.Claim(g.players[1], CardDuke), ErrSameFaction)
+	is.NoErr(g.DoAction())
+
+	// everyone may be targeted once every living player shares one faction
+	g.players[2].Hand = Hand{CardDuke | cardRevealed, CardContessa | cardRevealed}
+	is.True(g.mayTarget(0, 1))
+}
+
+func TestGameReserve(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke, CardCaptain}, Hand{CardDuke, CardContessa})
+	g.reformation = true
+	g.players[0].Faction, g.players[1].Faction = FactionLoyalist, FactionReformist
+
+	is := is.New(t)
+	is.Equal(g.Reserve(), uint8(0))
+
+	g.players[0].Coins = 2
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionConvert, AgainstID: newUint8(1)}))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+
+	is.Equal(g.Reserve(), DefaultRules().ConvertOtherCost)
+	is.Equal(g.players[0].Coins, uint8(0))
+	is.Equal(g.players[1].Faction, FactionLoyalist)
+}
+
+func TestGameProveInverted(t *testing.T) {
+	g := newTestGame(t, Hand{CardCaptain, CardContessa}, Hand{CardDuke, CardDuke}, Hand{CardAssassin, CardAssassin})
+
+	is := is.New(t)
+
+	embezzle := Action{AuthorID: 0, Kind: ActionEmbezzle}
+	is.Equal(g.Action(embezzle), ErrInvalidReformation)
+	g.reformation = true
+
+	// there is nothing to embezzle
+	is.Equal(g.Action(embezzle), ErrEmptyReserve)
+	g.reserve = 3
+
+	is.NoErr(g.Action(embezzle))
+	is.Equal(g.Phase(), PhaseReaction)
+	is.Equal(g.history[len(g.history)-1].Action, Action{AuthorID: 0, Kind: ActionClaim, Character: CardDuke, Inverted: true})
+
+	// the author has no Duke; the challenger loses
+	is.NoErr(g.ClaimChallenge(g.players[2]))
+	is.Equal(g.history[len(g.history)-3].Action, Action{
+		AuthorID:  0,
+		AgainstID: newUint8(2),
+		Kind:      ActionClaimProof,
+		Character: CardDuke,
+		Cards:     []uint8{CardCaptain, CardContessa},
+		Inverted:  true,
+	})
+	for k, v := range g.history[len(g.history)-2:] {
+		is.Equal(v.Kind, ActionClaimTakeCard)
+		is.Equal(*v.AssassinPlace, uint8(k))
+		is.Equal(v.Cards[0], g.players[0].Hand[k])
+	}
+
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+	is.NoErr(g.LoseInfluence(g.players[2], 0))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Coins, uint8(3))
+	is.Equal(g.Reserve(), uint8(0))
+
+	// the author has a Duke; the embezzlement fails
+	g.NextTurn()
+	g.reserve = 2
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionEmbezzle}))
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+	is.NoErr(g.LoseInfluence(g.players[1], 0))
+
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.Equal(g.players[1].Coins, uint8(0))
+	is.Equal(g.Reserve(), uint8(2))
+}
@@ -0,0 +1,279 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	ErrInvalidReplay = fmt.Errorf("history cannot be replayed")
+	ErrReplayEnded   = fmt.Errorf("history has been replayed entirely")
+)
+
+// Replayer is a structure that rebuilds a Game out of its full history;
+// i.e. Snapshot.History. Every step feeds the Game the input that
+// produced the next entry of the history, and checks that the Game
+// produced the exact same entries. Replays, audits and bug reproductions
+// are built on top of it. See Replay.
+//
+// Private entries must be kept as they are; the history returned by
+// Game.HistoryFor cannot be replayed.
+//
+// Every input leaves an entry behind; even the draw of an Inquisitor's
+// exchange. See ActionExchangeDraw. The picks of the two-player variant
+// are only stored once both players have picked, so Replayer feeds them
+// together. See Replayer.Step.
+// Turns are ended whenever the next entry belongs to a later turn, so
+// turns skipped through Game.NextTurn before they ended are replayed too.
+// See Entry.Turn.
+type Replayer struct {
+	g       *Game
+	players []*Player
+	history []Entry
+}
+
+// Replay is a function that rebuilds the Game that produced history out
+// of its seed and its RuleSet. opts must hold the options the Game was
+// created with besides WithSeed and WithRules; i.e. WithReformation.
+//
+// Replay returns the Game once the whole history has been replayed. To
+// stop at an earlier entry, see Replayer.Seek.
+func Replay(seed int64, rules RuleSet, history []Entry, opts ...Option) (*Game, error) {
+	r, err := NewReplayer(seed, rules, history, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.Seek(len(history)); err != nil {
+		return nil, err
+	}
+
+	return r.Game(), nil
+}
+
+// NewReplayer creates a Replayer for history. The seats of the Game are
+// deduced from the ActionDeal entries history starts with; a history
+// without them returns ErrInvalidReplay. The Game is created right away,
+// so that its deals are checked against the history too.
+func NewReplayer(seed int64, rules RuleSet, history []Entry, opts ...Option) (*Replayer, error) {
+	seats := 0
+	for _, v := range history {
+		if v.Kind != ActionDeal {
+			break
+		}
+
+		if int(v.AuthorID) >= seats {
+			seats = int(v.AuthorID) + 1
+		}
+	}
+
+	if seats == 0 {
+		return nil, ErrInvalidReplay
+	}
+
+	pl := make([]*Player, seats)
+	for _, v := range history {
+		if v.Kind != ActionDeal {
+			break
+		}
+
+		pl[v.AuthorID] = &Player{}
+	}
+
+	opts = append(append([]Option{}, opts...), WithSeed(seed), WithRules(rules))
+	g, err := NewGameWithPlayers(pl, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Replayer{g: g, players: pl, history: history}
+	if err := r.check(0); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Game returns the Game being replayed.
+func (r *Replayer) Game() *Game { return r.g }
+
+// Players returns the seats of the Game being replayed; nil for an empty
+// one.
+func (r *Replayer) Players() []*Player { return append([]*Player{}, r.players...) }
+
+// Position returns the amount of entries of the history the Game has
+// produced so far.
+func (r *Replayer) Position() int {
+	r.g.historyMtx.Lock()
+	defer r.g.historyMtx.Unlock()
+
+	return len(r.g.history)
+}
+
+// Seek is a function that steps through the history until the Game has
+// produced at-least n entries. The Game is then left in the state it was
+// right after the input that produced entry n-1. See Replayer.Step.
+func (r *Replayer) Seek(n int) error {
+	if n > len(r.history) {
+		return ErrReplayEnded
+	}
+
+	for r.Position() < n {
+		if err := r.Step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Step is a function that feeds the Game the input that produces the
+// next entry of the history, and checks the entries the Game produced.
+//
+// Step returns ErrReplayEnded once the whole history has been replayed.
+// Otherwise, the error of the input, or ErrInvalidReplay if the Game
+// produced a different entry, is returned along with the index of the
+// entry.
+func (r *Replayer) Step() error {
+	at := r.Position()
+	if at >= len(r.history) {
+		return ErrReplayEnded
+	}
+
+	if err := r.feed(r.history[at]); err != nil {
+		return fmt.Errorf("entry %d: %w", at, err)
+	}
+
+	return r.check(at)
+}
+
+// sameEntry returns true if both entries of a history are the same,
+// regardless of the players they point to and of their Time.
+func sameEntry(a, b Entry) bool {
+	a, b = a.clone(), b.clone()
+	a.Action, b.Action = detach(a.Action), detach(b.Action)
+	a.Time, b.Time = time.Time{}, time.Time{}
+	if len(a.Cards) == 0 {
+		a.Cards = nil
+	}
+	if len(b.Cards) == 0 {
+		b.Cards = nil
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// check returns ErrInvalidReplay if an entry the Game produced since
+// from differs from the history.
+func (r *Replayer) check(from int) error {
+	r.g.historyMtx.Lock()
+	defer r.g.historyMtx.Unlock()
+
+	for i := from; i < len(r.g.history) && i < len(r.history); i++ {
+		if !sameEntry(r.g.history[i], r.history[i]) {
+			return fmt.Errorf("entry %d: %w", i, ErrInvalidReplay)
+		}
+	}
+
+	return nil
+}
+
+// player returns the player seated at index; nil if there is none.
+func (r *Replayer) player(index uint8) *Player {
+	if int(index) >= len(r.players) {
+		return nil
+	}
+
+	return r.players[index]
+}
+
+// feed calls the method of the Game that produces the entry e from the
+// current phase. The turns before the one of e are ended first. See
+// Game.NextTurn.
+func (r *Replayer) feed(e Entry) error {
+	g := r.g
+	for n := g.turnNumber(); n < e.Turn; n = g.turnNumber() {
+		if g.IsOver() {
+			return ErrGameOver
+		}
+
+		// no turn ends while the hands are being selected.
+		if g.NextTurn(); g.turnNumber() == n {
+			return ErrInvalidReplay
+		}
+	}
+
+	author := r.player(e.AuthorID)
+	switch g.Phase() {
+	case PhaseHandSelection:
+		// the picks are only stored once every player has picked.
+		for _, v := range r.history[r.Position():] {
+			if v.Kind != ActionHandSelection || len(v.Cards) == 0 {
+				break
+			}
+
+			if err := g.SelectHand(r.player(v.AuthorID), v.Cards[0]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case PhaseReaction, PhaseBlock, PhaseBlockChallenge:
+		switch e.Kind {
+		case ActionReactionPass:
+			return g.Pass(author)
+		case ActionClaimChallenge:
+			return g.ClaimChallenge(author)
+		case ActionClaimPassed:
+			return g.ClaimPass()
+		case ActionClaim:
+			return g.Claim(author, e.Character)
+		}
+
+		// the window was closed without a reaction.
+		return g.DoAction()
+	case PhaseProof:
+		if e.Kind != ActionClaimProof {
+			return ErrInvalidReplay
+		}
+
+		_, err := g.ClaimProve(e.Character)
+		return err
+	case PhaseInfluenceLoss:
+		if e.Kind != ActionInfluenceLoss || e.AssassinPlace == nil {
+			return ErrInvalidReplay
+		}
+
+		return g.LoseInfluence(author, *e.AssassinPlace)
+	case PhaseShowCard:
+		if e.Kind != ActionShowCard || e.AssassinPlace == nil {
+			return ErrInvalidReplay
+		}
+
+		return g.ShowCard(author, *e.AssassinPlace)
+	case PhaseExamine:
+		if e.Kind != ActionExamine {
+			return ErrInvalidReplay
+		}
+
+		return g.Examine(author, e.AssassinPlace != nil)
+	case PhaseResolve:
+		return g.DoAction()
+	case PhaseExchange:
+		return g.Action(Action{AuthorID: e.AuthorID, Kind: e.Kind, Character: e.Character, Cards: e.Cards})
+	}
+
+	if e.Kind == ActionExchangeDraw {
+		// the Inquisitor draws once its Action is known.
+		return g.Action(Action{AuthorID: e.AuthorID, Kind: ActionCharacter, Character: e.Character})
+	} else if e.Kind == ActionClaim && e.Inverted {
+		return g.Action(Action{AuthorID: e.AuthorID, Kind: ActionEmbezzle})
+	} else if e.Kind == ActionClaim {
+		return g.Claim(author, e.Character)
+	} else if !e.Declared {
+		return ErrInvalidReplay
+	}
+
+	return g.Action(Action{AuthorID: e.AuthorID, Kind: e.Kind, Character: e.Character, AgainstID: e.AgainstID, Cards: e.Cards})
+}
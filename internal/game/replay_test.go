@@ -0,0 +1,285 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// playTestGame plays a few turns of a seeded Game of three players; an
+// income, a foreign aid blocked by a Duke, a challenged Captain, an
+// exchange and a Coup. It returns the Game along with its rules.
+func playTestGame(t *testing.T) (*Game, RuleSet) {
+	is := is.New(t)
+
+	rules := DefaultRules()
+	rules.CoupCost = 2
+
+	pl := []*Player{{}, {}, {}}
+	g, err := NewGameWithPlayers(pl, WithSeed(1), WithRules(rules))
+	is.NoErr(err)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionIncome}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionFinancialAid}))
+	is.NoErr(g.Pass(pl[0]))
+	is.NoErr(g.Claim(pl[2], CardDuke))
+	is.NoErr(g.Pass(pl[0]))
+	is.NoErr(g.Pass(pl[1]))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	is.NoErr(g.Claim(pl[2], CardCaptain))
+	is.NoErr(g.ClaimChallenge(pl[0]))
+	_, err = g.ClaimProve(pl[2].Hand[0])
+	is.NoErr(err)
+	for _, v := range pl {
+		if g.Phase() == PhaseInfluenceLoss && g.LoseInfluence(v, 0) == nil {
+			break
+		}
+	}
+	if g.Phase() == PhaseAction {
+		is.NoErr(g.Action(Action{AuthorID: 2, Kind: ActionCharacter, Character: CardCaptain, AgainstID: newUint8(1)}))
+		is.NoErr(g.DoAction())
+	}
+	g.NextTurn()
+
+	is.NoErr(g.Claim(pl[0], CardAmbassador))
+	is.NoErr(g.ClaimPass())
+	places := len(pl[0].Hand.places())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAmbassador, Cards: g.ExchangePool(0)[:places]}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionCoup, AgainstID: newUint8(0)}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.Phase(), PhaseInfluenceLoss)
+
+	return g, rules
+}
+
+func TestReplay(t *testing.T) {
+	g, rules := playTestGame(t)
+
+	is := is.New(t)
+
+	history := g.Snapshot().History
+	r, err := Replay(1, rules, history)
+	is.NoErr(err)
+	is.Equal(snapshotOf(r), snapshotOf(g))
+
+	// a different seed deals different cards
+	_, err = Replay(2, rules, history)
+	is.True(errors.Is(err, ErrInvalidReplay))
+
+	_, err = Replay(1, rules, nil)
+	is.Equal(err, ErrInvalidReplay)
+}
+
+func TestReplayerSeek(t *testing.T) {
+	g, rules := playTestGame(t)
+	history := g.Snapshot().History
+
+	is := is.New(t)
+
+	r, err := NewReplayer(1, rules, history)
+	is.NoErr(err)
+	is.Equal(len(r.Players()), 3)
+	is.Equal(r.Position(), 3)
+
+	// stop right after the Duke blocked the foreign aid
+	block := 0
+	for k, v := range history {
+		if v.Kind == ActionClaim && v.Character == CardDuke {
+			block = k
+			break
+		}
+	}
+
+	is.NoErr(r.Seek(block + 1))
+	is.Equal(r.Position(), block+1)
+	is.Equal(r.Game().Phase(), PhaseBlockChallenge)
+	is.Equal(*r.Game().primaryAction(), Action{AuthorID: 1, author: r.Players()[1], Kind: ActionFinancialAid})
+
+	is.NoErr(r.Seek(len(history)))
+	is.Equal(r.Step(), ErrReplayEnded)
+	is.Equal(r.Seek(len(history)+1), ErrReplayEnded)
+}
+
+func TestReplayerStep(t *testing.T) {
+	g, rules := playTestGame(t)
+	history := g.Snapshot().History
+
+	is := is.New(t)
+
+	// the Game would have dealt other cards
+	history[0].Cards = []uint8{history[0].Cards[1], history[0].Cards[0]}
+	if history[0].Cards[0] != history[0].Cards[1] {
+		_, err := NewReplayer(1, rules, history)
+		is.True(errors.Is(err, ErrInvalidReplay))
+	}
+	history[0].Cards = g.Snapshot().History[0].Cards
+
+	// the income is declared, then done
+	r, err := NewReplayer(1, rules, history)
+	is.NoErr(err)
+	is.NoErr(r.Step())
+	is.Equal(r.Game().Phase(), PhaseResolve)
+	is.NoErr(r.Step())
+	is.Equal(r.Position(), 5)
+
+	// nothing is proven at the start of a turn
+	history[3].Action = Action{AuthorID: 0, Kind: ActionClaimProof, Character: CardDuke}
+	r, err = NewReplayer(1, rules, history)
+	is.NoErr(err)
+	is.True(errors.Is(r.Step(), ErrInvalidReplay))
+}
+
+func TestReplaySkippedTurn(t *testing.T) {
+	is := is.New(t)
+
+	pl := []*Player{{}, {}, {}}
+	g, err := NewGameWithPlayers(pl, WithSeed(4))
+	is.NoErr(err)
+
+	// the turn times out after the claim passed
+	is.NoErr(g.Claim(pl[0], CardDuke))
+	is.NoErr(g.ClaimPass())
+	g.NextTurn()
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionIncome}))
+	is.NoErr(g.DoAction())
+
+	history := g.Snapshot().History
+	is.Equal(history[len(history)-1].Turn, uint(1))
+
+	r, err := Replay(4, DefaultRules(), history)
+	is.NoErr(err)
+	is.Equal(snapshotOf(r), snapshotOf(g))
+}
+
+func TestReplayExchangeTimeout(t *testing.T) {
+	is := is.New(t)
+
+	pl := []*Player{{}, {}, {}}
+	g, err := NewGameWithPlayers(pl, WithSeed(5), WithInquisitor())
+	is.NoErr(err)
+
+	// the turn times out once the Inquisitor has drawn
+	is.NoErr(g.Claim(pl[0], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	exchange := Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor}
+	is.NoErr(g.Action(exchange))
+	is.Equal(g.Phase(), PhaseExchange)
+
+	draw := g.history[len(g.history)-1]
+	is.Equal(draw.Kind, ActionExchangeDraw)
+	is.Equal(draw.Cards, g.ExchangePool(0)[2:])
+	g.NextTurn()
+
+	// the next draw depends on the shuffle of the skipped exchange
+	is.NoErr(g.Claim(pl[1], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	exchange.AuthorID = 1
+	is.NoErr(g.Action(exchange))
+	exchange.Cards = g.ExchangePool(1)[1:]
+	is.NoErr(g.Action(exchange))
+	is.NoErr(g.DoAction())
+
+	r, err := Replay(5, DefaultRules(), g.Snapshot().History, WithInquisitor())
+	is.NoErr(err)
+	is.Equal(snapshotOf(r), snapshotOf(g))
+}
+
+func TestReplayExpansions(t *testing.T) {
+	is := is.New(t)
+
+	opts := []Option{WithTwoPlayerVariant(), WithInquisitor(), WithReformation()}
+	pl := []*Player{{}, {}}
+	g, err := NewGameWithPlayers(pl, append(opts, WithSeed(3))...)
+	is.NoErr(err)
+
+	is.NoErr(g.SelectHand(pl[1], CardInquisitor))
+	is.NoErr(g.SelectHand(pl[0], CardInquisitor))
+
+	is.NoErr(g.Claim(pl[0], CardInquisitor))
+	is.NoErr(g.Pass(pl[1]))
+	exchange := Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor}
+	is.NoErr(g.Action(exchange))
+	exchange.Cards = g.ExchangePool(0)[1:]
+	is.NoErr(g.Action(exchange))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	is.NoErr(g.Action(Action{AuthorID: 1, Kind: ActionConvert}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+
+	is.NoErr(g.Claim(pl[0], CardInquisitor))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardInquisitor, AgainstID: newUint8(1)}))
+	is.NoErr(g.DoAction())
+	is.NoErr(g.ShowCard(pl[1], 1))
+	is.NoErr(g.Examine(pl[0], true))
+
+	r, err := Replay(3, DefaultRules(), g.Snapshot().History, opts...)
+	is.NoErr(err)
+	is.Equal(snapshotOf(r), snapshotOf(g))
+}
+
+// TestReplayRandomGames plays random games through LegalMoves, where
+// turns sometimes time out, and makes sure they replay the same way.
+func TestReplayRandomGames(t *testing.T) {
+	configs := [][]Option{
+		nil,
+		{WithReformation(), WithInquisitor()},
+		{WithTwoPlayerVariant(), WithInquisitor()},
+	}
+
+	for k, opts := range configs {
+		for seed := int64(1); seed <= 10; seed++ {
+			pl := []*Player{{}, {}, {}, {}}
+			if k == 2 {
+				pl = pl[:2]
+			}
+
+			g, err := NewGameWithPlayers(pl, append([]Option{WithSeed(seed)}, opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := rand.New(rand.NewSource(seed))
+			for step := 0; step < 300 && !g.IsOver(); step++ {
+				decisions := g.Pending().Decisions
+				if g.Phase() == PhaseTurnEnd || (len(decisions) > 0 && r.Intn(10) == 0) {
+					g.NextTurn()
+					continue
+				} else if len(decisions) == 0 {
+					if err := g.DoAction(); err != nil {
+						t.Fatalf("%d/%d: %v", k, seed, err)
+					}
+
+					continue
+				}
+
+				d := decisions[r.Intn(len(decisions))]
+				moves := g.LegalMoves(int(d.Player))
+				if err := play(g, int(d.Player), moves[r.Intn(len(moves))]); err != nil {
+					t.Fatalf("%d/%d: %v", k, seed, err)
+				}
+			}
+
+			replayed, err := Replay(seed, g.rules, g.Snapshot().History, opts...)
+			if err != nil {
+				t.Fatalf("%d/%d: %v", k, seed, err)
+			} else if !reflect.DeepEqual(snapshotOf(replayed), snapshotOf(g)) {
+				t.Fatalf("%d/%d: the replayed game differs", k, seed)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// RuleSet is a structure describing the amounts of coins and cards a Game
+// is played with. Every action function of a Game consults the RuleSet
+// the Game was created with. See WithRules.
+//
+// The zero value of RuleSet is valid but hardly playable; start from
+// DefaultRules or load one from JSON via LoadRules instead.
+type RuleSet struct {
+	// StartingCoins is the amount of coins every player starts with.
+	StartingCoins uint8 `json:"starting_coins"`
+	// Income is the amount of coins ActionIncome pays.
+	Income uint8 `json:"income"`
+	// ForeignAid is the amount of coins ActionFinancialAid pays.
+	ForeignAid uint8 `json:"foreign_aid"`
+	// Tax is the amount of coins the Duke pays.
+	Tax uint8 `json:"tax"`
+	// Steal is the most coins the Captain steals from their target.
+	Steal uint8 `json:"steal"`
+	// CoupCost is the amount of coins a Coup costs.
+	CoupCost uint8 `json:"coup_cost"`
+	// AssassinCost is the amount of coins an assassination costs.
+	AssassinCost uint8 `json:"assassin_cost"`
+	// MandatoryCoup is the amount of coins at which a player must launch
+	// a Coup. Zero means that a Coup is never mandatory.
+	MandatoryCoup uint8 `json:"mandatory_coup"`
+	// CoinCap is the most coins a player can hold; any coin past it is
+	// left where it came from. Zero means that there is no cap.
+	CoinCap uint8 `json:"coin_cap"`
+	// ConvertCost is the amount of coins the author of ActionConvert
+	// pays to change their own faction.
+	ConvertCost uint8 `json:"convert_cost"`
+	// ConvertOtherCost is the amount of coins the author of ActionConvert
+	// pays to change the faction of another player.
+	ConvertOtherCost uint8 `json:"convert_other_cost"`
+	// Copies is the amount of copies of every character in the deck.
+	// Zero means that the deck grows with the amount of seated players.
+	// See copiesFor.
+	Copies uint8 `json:"copies"`
+}
+
+// DefaultRules returns the RuleSet of the base game.
+func DefaultRules() RuleSet {
+	return RuleSet{
+		StartingCoins:    2,
+		Income:           1,
+		ForeignAid:       2,
+		Tax:              3,
+		Steal:            2,
+		CoupCost:         7,
+		AssassinCost:     3,
+		MandatoryCoup:    10,
+		ConvertCost:      1,
+		ConvertOtherCost: 2,
+	}
+}
+
+// LoadRules is a function that reads a RuleSet out of a JSON object. Any
+// field missing from the object keeps its value from DefaultRules, while
+// unknown fields are rejected. The RuleSet is validated before it is
+// returned. See RuleSet.Validate.
+func LoadRules(data []byte) (RuleSet, error) {
+	r := DefaultRules()
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&r); err != nil {
+		return RuleSet{}, err
+	}
+
+	if err := r.Validate(); err != nil {
+		return RuleSet{}, err
+	}
+
+	return r, nil
+}
+
+// Validate returns ErrInvalidRules if a Game cannot be played with the
+// RuleSet; i.e. a mandatory Coup that cannot be paid for, or a CoinCap
+// below the cost of a Coup or the starting coins.
+//
+// Do note: Whether the deck is big enough is only known once the
+//          players are seated. See NewGameWithPlayers.
+func (r RuleSet) Validate() error {
+	if r.MandatoryCoup != 0 && r.MandatoryCoup < r.CoupCost {
+		return ErrInvalidRules
+	} else if r.CoinCap != 0 && (r.CoinCap < r.CoupCost || r.CoinCap < r.StartingCoins) {
+		return ErrInvalidRules
+	}
+
+	return nil
+}
+
+// Rules returns the RuleSet the Game is played with. See WithRules.
+func (g *Game) Rules() RuleSet {
+	return g.rules
+}
+
+// copies returns the amount of copies of every character in the deck of
+// a Game with the given amount of players.
+func (r RuleSet) copies(players int) int {
+	if r.Copies != 0 {
+		return int(r.Copies)
+	}
+
+	return copiesFor(players)
+}
+
+// mustCoup returns true if a player holding coins must launch a Coup.
+func (r RuleSet) mustCoup(coins uint8) bool {
+	return r.MandatoryCoup != 0 && coins >= r.MandatoryCoup
+}
+
+// variantCoins returns the amount of coins the starting player of the
+// two-player variant starts with. See WithTwoPlayerVariant.
+func (r RuleSet) variantCoins() uint8 {
+	if r.StartingCoins < variantStartingCoins {
+		return r.StartingCoins
+	}
+
+	return variantStartingCoins
+}
+
+// gain is the same as coinsPlus but it stops at CoinCap.
+func (r RuleSet) gain(coins uint8, plus uint8) uint8 {
+	sum := coinsPlus(coins, plus)
+	if r.CoinCap != 0 && sum > r.CoinCap {
+		if coins > r.CoinCap {
+			return coins
+		}
+
+		return r.CoinCap
+	}
+
+	return sum
+}
+
+// IncomeAction is a function that adds Income to the amount of coins
+// that was supplied to it.
+func (r RuleSet) IncomeAction(coins uint8) uint8 { return r.gain(coins, r.Income) }
+
+// FinancialAidAction is the same as IncomeAction but it adds ForeignAid
+// instead.
+func (r RuleSet) FinancialAidAction(coins uint8) uint8 { return r.gain(coins, r.ForeignAid) }
+
+// DukeAction is the same as IncomeAction but it adds Tax instead.
+func (r RuleSet) DukeAction(coins uint8) uint8 { return r.gain(coins, r.Tax) }
+
+// CoupAction is the same as the package's CoupAction but it costs
+// CoupCost.
+func (r RuleSet) CoupAction(coins uint8, place uint8, hand Hand) (uint8, Hand) {
+	return minusCoinsRemoveFromHand(r.CoupCost, coins, place, hand)
+}
+
+// AssassinAction is the same as the package's AssassinAction but it
+// costs AssassinCost.
+func (r RuleSet) AssassinAction(coins uint8, place uint8, hand Hand) (uint8, Hand) {
+	return minusCoinsRemoveFromHand(r.AssassinCost, coins, place, hand)
+}
+
+// CaptainAction is a function that steals up to Steal coins from another
+// player. The Captain never steals more than the target has, nor more
+// than CoinCap lets them hold.
+func (r RuleSet) CaptainAction(captainCoins uint8, targetCoins uint8) (uint8, uint8) {
+	steal := r.Steal
+	if targetCoins < steal {
+		steal = targetCoins
+	}
+
+	coins := r.gain(captainCoins, steal)
+	return coins, targetCoins - (coins - captainCoins)
+}
+
+// EmbezzleAction is the same as the package's EmbezzleAction but the
+// coins past CoinCap stay in the reserve.
+func (r RuleSet) EmbezzleAction(coins uint8, reserve uint8) (uint8, uint8) {
+	gained := r.gain(coins, reserve)
+	return gained, reserve - (gained - coins)
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLoadRules(t *testing.T) {
+	is := is.New(t)
+
+	r, err := LoadRules([]byte(`{"coup_cost": 5, "mandatory_coup": 8, "copies": 2}`))
+	is.NoErr(err)
+
+	want := DefaultRules()
+	want.CoupCost, want.MandatoryCoup, want.Copies = 5, 8, 2
+	is.Equal(r, want)
+
+	_, err = LoadRules([]byte(`{"coup_costs": 5}`))
+	is.True(err != nil)
+	_, err = LoadRules([]byte(`{"coup_cost": -1}`))
+	is.True(err != nil)
+	_, err = LoadRules([]byte(`{"coup_cost": 11}`))
+	is.Equal(err, ErrInvalidRules)
+}
+
+func TestRuleSetValidate(t *testing.T) {
+	is := is.New(t)
+
+	r := DefaultRules()
+	is.NoErr(r.Validate())
+
+	// a mandatory coup must be affordable
+	r.MandatoryCoup = r.CoupCost - 1
+	is.Equal(r.Validate(), ErrInvalidRules)
+	r.MandatoryCoup = 0
+	is.NoErr(r.Validate())
+
+	r.CoinCap = r.CoupCost - 1
+	is.Equal(r.Validate(), ErrInvalidRules)
+	r.CoinCap = r.CoupCost
+	is.NoErr(r.Validate())
+}
+
+func TestRuleSetGain(t *testing.T) {
+	is := is.New(t)
+
+	r := DefaultRules()
+	is.Equal(r.gain(9, 3), uint8(12))
+	is.Equal(r.gain(254, 3), uint8(255))
+
+	r.CoinCap = 10
+	is.Equal(r.gain(9, 3), uint8(10))
+	is.Equal(r.gain(11, 3), uint8(11))
+}
+
+func TestRuleSetCaptainAction(t *testing.T) {
+	is := is.New(t)
+
+	r := DefaultRules()
+	r.Steal = 3
+
+	captain, target := r.CaptainAction(0, 4)
+	is.Equal(captain, uint8(3))
+	is.Equal(target, uint8(1))
+
+	captain, target = r.CaptainAction(0, 2)
+	is.Equal(captain, uint8(2))
+	is.Equal(target, uint8(0))
+
+	// the target keeps what the Captain cannot hold
+	r.CoinCap = 8
+	captain, target = r.CaptainAction(7, 4)
+	is.Equal(captain, uint8(8))
+	is.Equal(target, uint8(3))
+}
+
+func TestRuleSetEmbezzleAction(t *testing.T) {
+	is := is.New(t)
+
+	r := DefaultRules()
+	coins, reserve := r.EmbezzleAction(1, 5)
+	is.Equal(coins, uint8(6))
+	is.Equal(reserve, uint8(0))
+
+	r.CoinCap = 7
+	coins, reserve = r.EmbezzleAction(5, 5)
+	is.Equal(coins, uint8(7))
+	is.Equal(reserve, uint8(3))
+}
+
+func TestGameRules(t *testing.T) {
+	is := is.New(t)
+
+	r := DefaultRules()
+	r.StartingCoins, r.CoupCost, r.Income, r.Copies, r.CoinCap = 4, 5, 2, 2, 7
+
+	pl := [5]*Player{{}, {}}
+	g, err := NewGame(pl, WithRules(r), WithSeed(1))
+	is.NoErr(err)
+	is.Equal(g.Rules(), r)
+	is.Equal(len(g.deck), 2*5-4)
+	is.Equal(pl[0].Coins, uint8(4))
+	is.Equal(g.Treasury(), defaultTreasury-8)
+
+	// the cap keeps the rest of the income in the treasury
+	pl[0].Coins = 6
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionIncome}))
+	is.NoErr(g.DoAction())
+	is.Equal(pl[0].Coins, uint8(7))
+	is.Equal(g.Treasury(), defaultTreasury-9)
+
+	g.NextTurn()
+	g.NextTurn()
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}))
+	is.NoErr(g.DoAction())
+	is.Equal(pl[0].Coins, uint8(2))
+
+	// the deck must be dealt with enough cards left for an exchange
+	r.Copies = 1
+	_, err = NewGame(pl, WithRules(r))
+	is.Equal(err, ErrInvalidRules)
+
+	r.Copies, r.CoinCap = 0, 1
+	_, err = NewGame(pl, WithRules(r))
+	is.Equal(err, ErrInvalidRules)
+}
@@ -0,0 +1,451 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// snapshotVersion is the version of Snapshot. It must grow with every
+// change that snapshots of an older version cannot be restored with.
+const snapshotVersion uint8 = 4
+
+var (
+	ErrInvalidSnapshot = fmt.Errorf("snapshot doesn't describe a valid game")
+	ErrSnapshotVersion = fmt.Errorf("snapshot version is not supported")
+)
+
+// countingSource is a rand.Source that counts the values it has
+// produced. A seeded source is brought back to the same state by
+// drawing as many values from a new source with the same seed. See
+// Restore.
+type countingSource struct {
+	src   rand.Source
+	draws uint64
+}
+
+func (s *countingSource) Int63() int64 {
+	s.draws++
+	return s.src.Int63()
+}
+
+func (s *countingSource) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.draws = 0
+}
+
+// Snapshot is a structure holding everything needed to resume a Game;
+// the deck, the seats, the stack of the current turn with the results of
+// its claims, the phase, the turn and the history. See Game.Snapshot and
+// Restore.
+//
+// Players are referred to by their index, like in Action.
+//
+// Do note: The random source of a Game created with WithSource cannot be
+//          resumed. Its restored Game shuffles with a new source.
+type Snapshot struct {
+	// Version is the version of the Snapshot. Restore returns
+	// ErrSnapshotVersion for any version but the current one.
+	Version uint8 `json:"version"`
+	Seed    int64 `json:"seed"`
+	Seeded  bool  `json:"seeded"`
+	// Draws is the amount of values the random source of the Game has
+	// produced so far.
+	Draws uint64  `json:"draws"`
+	Deck  []uint8 `json:"deck"`
+	// Players holds every seat of the Game; nil for an empty one.
+	Players    []*SnapshotPlayer `json:"players"`
+	Turn       int               `json:"turn"`
+	Phase      uint8             `json:"phase"`
+	PhaseStart time.Time         `json:"phase_start"`
+	Timeout    time.Duration     `json:"timeout"`
+	// Stack holds the claims and the actions of the current turn; the
+	// primary one first.
+	Stack       []SnapshotFrame      `json:"stack,omitempty"`
+	Loss        *SnapshotLoss        `json:"loss,omitempty"`
+	Examination *SnapshotExamination `json:"examination,omitempty"`
+	Exchange    Hand                 `json:"exchange"`
+	Passed      []uint8              `json:"passed,omitempty"`
+	History     []Entry              `json:"history"`
+	Treasury    uint8                `json:"treasury"`
+	Reserve     uint8                `json:"reserve"`
+	Eliminated  []uint8              `json:"eliminated,omitempty"`
+	Over        bool                 `json:"over"`
+	Winner      uint8                `json:"winner"`
+	Variant     bool                 `json:"variant"`
+	Selection   [][]uint8            `json:"selection,omitempty"`
+	Picks       []uint8              `json:"picks,omitempty"`
+	Reformation bool                 `json:"reformation"`
+	Characters  []uint8              `json:"characters"`
+	Rules       RuleSet              `json:"rules"`
+	// Turns is the number of the current turn. See Entry.Turn.
+	Turns uint `json:"turns"`
+}
+
+// SnapshotPlayer is a structure holding a seated Player. See Snapshot.
+type SnapshotPlayer struct {
+	Coins   uint8 `json:"coins"`
+	Hand    Hand  `json:"hand"`
+	Faction uint8 `json:"faction"`
+	// Dead is true once Player.IsDead has returned true.
+	Dead bool `json:"dead"`
+}
+
+// SnapshotClaim is a structure holding a claim of the stack, along with
+// its results. See Snapshot.
+type SnapshotClaim struct {
+	AuthorID     uint8  `json:"author_id"`
+	ChallengerID *uint8 `json:"challenger_id"`
+	Character    uint8  `json:"character"`
+	Inverted     bool   `json:"inverted,omitempty"`
+	Succeed      *bool  `json:"succeed"`
+	Challenge    *bool  `json:"challenge"`
+	// Seq, ChallengeSeq and ProofSeq are the Seq of the entries of the
+	// claim, its challenge and its proof. See Entry.Cause.
+	Seq          uint64 `json:"seq"`
+	ChallengeSeq uint64 `json:"challenge_seq"`
+	ProofSeq     uint64 `json:"proof_seq"`
+}
+
+// SnapshotFrame is a structure holding a frame of the stack; a claim
+// and the action it backs. Counter is true for a block. See Snapshot.
+type SnapshotFrame struct {
+	Claim   *SnapshotClaim `json:"claim,omitempty"`
+	Action  *Action        `json:"action,omitempty"`
+	Counter bool           `json:"counter,omitempty"`
+	// Seq is the Seq of the entry of the declared Action, if any.
+	Seq *uint64 `json:"seq,omitempty"`
+}
+
+// SnapshotLoss is a structure holding the player that must lose an
+// influence. See Game.LoseInfluence.
+type SnapshotLoss struct {
+	VictimID  uint8  `json:"victim_id"`
+	Challenge bool   `json:"challenge"`
+	Cause     uint64 `json:"cause"`
+}
+
+// SnapshotExamination is a structure holding the examination of an
+// Inquisitor. See Game.ShowCard.
+type SnapshotExamination struct {
+	InquisitorID uint8  `json:"inquisitor_id"`
+	TargetID     uint8  `json:"target_id"`
+	Place        *uint8 `json:"place"`
+	Cause        uint64 `json:"cause"`
+}
+
+// cloneCards returns a copy of arr; nil if arr is empty.
+func cloneCards(arr []uint8) []uint8 {
+	return append([]uint8(nil), arr...)
+}
+
+// detach returns a copy of the Action without its players; the Snapshot
+// only keeps their indexes.
+func detach(a Action) Action {
+	a.author, a.against = nil, nil
+	return a
+}
+
+// Snapshot is a function that captures the state of the Game. The Game
+// can be resumed from the Snapshot through Restore; even after it has
+// gone through JSON. See Game.MarshalJSON.
+//
+// Do note: Subscriptions to the turn are not part of the Snapshot.
+func (g *Game) Snapshot() Snapshot {
+	g.stackMtx.Lock()
+	defer g.stackMtx.Unlock()
+
+	s := Snapshot{
+		Version:     snapshotVersion,
+		Exchange:    g.exchange,
+		Passed:      cloneCards(g.passed),
+		Variant:     g.variant,
+		Reformation: g.reformation,
+		Characters:  g.characters(),
+		Rules:       g.rules,
+	}
+	seat := func(p *Player) uint8 { return uint8(findPlayerByPntr(g.players, p)) }
+
+	g.deckMtx.Lock()
+	s.Seed, s.Seeded, s.Deck = g.seed, g.seeded, cloneCards(g.deck)
+	if g.source != nil {
+		s.Draws = g.source.draws
+	}
+	g.deckMtx.Unlock()
+
+	for _, v := range g.players {
+		if v == nil {
+			s.Players = append(s.Players, nil)
+			continue
+		}
+
+		s.Players = append(s.Players, &SnapshotPlayer{Coins: v.Coins, Hand: v.Hand, Faction: v.Faction, Dead: v.dead})
+	}
+	s.Turn, _ = g.TurnGet()
+
+	g.phaseMtx.Lock()
+	s.Phase, s.PhaseStart, s.Timeout = g.phase, g.phaseStart, g.timeout
+	g.phaseMtx.Unlock()
+
+	for _, f := range g.stack {
+		frame := SnapshotFrame{Counter: f.counter}
+		if f.seq != nil {
+			seq := *f.seq
+			frame.Seq = &seq
+		}
+		if c := f.claim; c != nil {
+			frame.Claim = &SnapshotClaim{
+				AuthorID:     seat(c.author),
+				Character:    c.character,
+				Inverted:     c.inverted,
+				Seq:          c.seq,
+				ChallengeSeq: c.challengeSeq,
+				ProofSeq:     c.proofSeq,
+			}
+			if c.challenger != nil {
+				frame.Claim.ChallengerID = newUint8(seat(c.challenger))
+			}
+			frame.Claim.Succeed, frame.Claim.Challenge = c.Results()
+		}
+		if f.action != nil {
+			a := detach(*f.action)
+			frame.Action = &a
+		}
+
+		s.Stack = append(s.Stack, frame)
+	}
+
+	if g.loss != nil {
+		s.Loss = &SnapshotLoss{VictimID: seat(g.loss.victim), Challenge: g.loss.challenge, Cause: g.loss.cause}
+	}
+	if e := g.examination; e != nil {
+		s.Examination = &SnapshotExamination{InquisitorID: seat(e.inquisitor), TargetID: seat(e.target), Place: e.place, Cause: e.cause}
+	}
+
+	for _, v := range g.History(0, -1) {
+		v.Action = detach(v.Action)
+		s.History = append(s.History, v)
+	}
+	s.Turns = g.turnNumber()
+
+	g.treasuryMtx.Lock()
+	s.Treasury, s.Reserve = g.treasury, g.reserve
+	g.treasuryMtx.Unlock()
+
+	g.eliminatedMtx.Lock()
+	s.Eliminated, s.Over, s.Winner = cloneCards(g.eliminated), g.over, g.winner
+	g.eliminatedMtx.Unlock()
+
+	g.selectionMtx.Lock()
+	for _, v := range g.selection {
+		s.Selection = append(s.Selection, cloneCards(v))
+	}
+	s.Picks = cloneCards(g.picks)
+	g.selectionMtx.Unlock()
+
+	return s
+}
+
+// MarshalJSON returns the Snapshot of the Game as JSON. See Game.Snapshot.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Snapshot())
+}
+
+// Restore is a function that resumes the Game captured by the Snapshot.
+// Like NewGameWithPlayers, pl holds a seat for every seat of the
+// Snapshot; nil for an empty one. The Hand, Coins and Faction of every
+// seated player are overwritten with those of the Snapshot.
+//
+// Restore returns ErrSnapshotVersion if the Snapshot was taken by an
+// incompatible version of Game, and ErrInvalidSnapshot if pl doesn't
+// match its seats, if it refers to an empty seat or an unregistered
+// character, if its phase lacks the state it relies on or if the Seq of
+// its entries don't match their index. pl is left untouched when an error is returned.
+func Restore(s Snapshot, pl []*Player) (*Game, error) {
+	if s.Version != snapshotVersion {
+		return nil, ErrSnapshotVersion
+	} else if len(pl) != len(s.Players) || len(pl) > maxPlayers {
+		return nil, ErrInvalidSnapshot
+	} else if err := s.Rules.Validate(); err != nil {
+		return nil, err
+	} else if len(s.Characters) == 0 || !validRoster(s.Characters) {
+		return nil, ErrInvalidSnapshot
+	}
+
+	for k, v := range s.Players {
+		if (v == nil) != (pl[k] == nil) {
+			return nil, ErrInvalidSnapshot
+		}
+	}
+
+	g := &Game{
+		players:     append([]*Player{}, pl...),
+		deck:        cloneCards(s.Deck),
+		phase:       s.Phase,
+		phaseStart:  s.PhaseStart,
+		timeout:     s.Timeout,
+		exchange:    s.Exchange,
+		passed:      cloneCards(s.Passed),
+		turns:       s.Turns,
+		treasury:    s.Treasury,
+		reserve:     s.Reserve,
+		eliminated:  cloneCards(s.Eliminated),
+		over:        s.Over,
+		winner:      s.Winner,
+		variant:     s.Variant,
+		picks:       cloneCards(s.Picks),
+		reformation: s.Reformation,
+		roster:      cloneCards(s.Characters),
+		rules:       s.Rules,
+	}
+
+	for k, v := range s.Players {
+		if v != nil {
+			g.max = k + 1
+		}
+	}
+	for _, v := range s.Selection {
+		g.selection = append(g.selection, cloneCards(v))
+	}
+	for k, v := range s.History {
+		if v.Seq != uint64(k) {
+			return nil, ErrInvalidSnapshot
+		}
+
+		g.history = append(g.history, v.clone())
+	}
+
+	seat := func(index uint8) (*Player, error) {
+		if int(index) >= len(g.players) || g.players[index] == nil {
+			return nil, ErrInvalidSnapshot
+		}
+
+		return g.players[index], nil
+	}
+
+	for _, v := range s.Stack {
+		f := &frame{counter: v.Counter}
+		if v.Seq != nil {
+			seq := *v.Seq
+			f.seq = &seq
+		}
+		if v.Claim != nil {
+			author, err := seat(v.Claim.AuthorID)
+			if err != nil {
+				return nil, err
+			}
+
+			f.claim = &claim{
+				author:    author,
+				character: v.Claim.Character,
+				inverted:  v.Claim.Inverted,
+				succeed:   v.Claim.Succeed,
+				challenge: v.Claim.Challenge,
+
+				seq:          v.Claim.Seq,
+				challengeSeq: v.Claim.ChallengeSeq,
+				proofSeq:     v.Claim.ProofSeq,
+			}
+			if v.Claim.ChallengerID != nil {
+				if f.claim.challenger, err = seat(*v.Claim.ChallengerID); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if v.Action != nil {
+			a := *v.Action
+			if a.setPlayer(g.players) != nil {
+				return nil, ErrInvalidSnapshot
+			}
+			f.action = &a
+		}
+
+		g.push(f)
+	}
+
+	if s.Loss != nil {
+		victim, err := seat(s.Loss.VictimID)
+		if err != nil {
+			return nil, err
+		}
+
+		g.loss = &influenceLoss{victim: victim, challenge: s.Loss.Challenge, cause: s.Loss.Cause}
+	}
+
+	if e := s.Examination; e != nil {
+		inquisitor, err := seat(e.InquisitorID)
+		if err != nil {
+			return nil, err
+		}
+		target, err := seat(e.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		g.examination = &examination{inquisitor: inquisitor, target: target, place: e.Place, cause: e.Cause}
+	}
+
+	if !g.consistent() {
+		return nil, ErrInvalidSnapshot
+	} else if s.Turn < 0 || s.Turn >= len(g.players) || g.players[s.Turn] == nil {
+		return nil, ErrInvalidSnapshot
+	}
+	g.turn = NewNotifier()
+	g.turn.Set(s.Turn)
+
+	// the players are only overwritten once the Snapshot is known to be
+	// valid.
+	for k, v := range s.Players {
+		if v != nil {
+			pl[k].Coins, pl[k].Hand, pl[k].Faction, pl[k].dead = v.Coins, v.Hand, v.Faction, v.Dead
+		}
+	}
+
+	if !s.Seeded {
+		WithSource(rand.NewSource(time.Now().UnixNano()))(g)
+		return g, nil
+	}
+
+	WithSeed(s.Seed)(g)
+	for i := uint64(0); i < s.Draws; i++ {
+		g.source.Int63()
+	}
+
+	return g, nil
+}
+
+// consistent returns true if the phase of the Game is valid and the Game
+// holds the state it relies on; i.e. the victim of PhaseInfluenceLoss.
+// See Restore.
+func (g *Game) consistent() bool {
+	c := g.currentClaim()
+
+	// declared Actions are responded to through the Seq of their
+	// declaration. Blocks and Embezzlement are declared through their
+	// claim.
+	for _, f := range g.stack {
+		if f.action != nil && f.seq == nil && !f.counter && (f.claim == nil || !f.claim.inverted) {
+			return false
+		}
+	}
+
+	switch g.phase {
+	case PhaseReaction, PhaseBlockChallenge, PhaseProof, PhaseExchange:
+		return c != nil
+	case PhaseBlock:
+		return g.primaryAction() != nil
+	case PhaseResolve:
+		return len(g.stack) > 0
+	case PhaseInfluenceLoss:
+		return g.loss != nil && (!g.loss.challenge || c != nil)
+	case PhaseShowCard:
+		return g.examination != nil
+	case PhaseExamine:
+		return g.examination != nil && g.examination.place != nil
+	case PhaseHandSelection:
+		return g.variant && len(g.selection) > 0 && len(g.selection) == len(g.picks)
+	}
+
+	return IsValidPhase(g.phase)
+}
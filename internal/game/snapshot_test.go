@@ -0,0 +1,164 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+// snapshotOf returns the Snapshot of g without the start of its phase
+// and the time of its entries, which differ between two games played at
+// different times.
+func snapshotOf(g *Game) Snapshot {
+	s := g.Snapshot()
+	s.PhaseStart = time.Time{}
+	for k := range s.History {
+		s.History[k].Time = time.Time{}
+	}
+
+	return s
+}
+
+func TestCountingSource(t *testing.T) {
+	is := is.New(t)
+
+	src := &countingSource{src: rand.NewSource(1)}
+	r := rand.New(src)
+	r.Intn(10)
+	r.Intn(10)
+	is.Equal(src.draws, uint64(2))
+
+	src.Seed(1)
+	is.Equal(src.draws, uint64(0))
+}
+
+func TestGameSnapshot(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{{}, {}, {}}
+	g, err := NewGame(pl, WithSeed(1), WithReformation())
+	is.NoErr(err)
+
+	is.NoErr(g.Claim(pl[0], CardDuke))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardDuke}))
+	is.NoErr(g.DoAction())
+	g.NextTurn()
+	g.Shuffle()
+
+	// the claim is pending once the game is restored
+	is.NoErr(g.Claim(pl[1], CardCaptain))
+	is.NoErr(g.Pass(pl[2]))
+
+	data, err := json.Marshal(g)
+	is.NoErr(err)
+
+	s := Snapshot{}
+	is.NoErr(json.Unmarshal(data, &s))
+	is.Equal(s.Version, snapshotVersion)
+	is.Equal(s.Draws, g.source.draws)
+
+	restored := []*Player{{}, {}, {}, nil, nil}
+	r, err := Restore(s, restored)
+	is.NoErr(err)
+	is.Equal(snapshotOf(r), snapshotOf(g))
+	is.Equal(*restored[1], *pl[1])
+	is.Equal(r.Pending(), g.Pending())
+
+	// both games carry on the exact same way
+	for _, v := range []*Game{g, r} {
+		players := v.players
+
+		is.NoErr(v.ClaimChallenge(players[0]))
+		v.Shuffle()
+	}
+	is.Equal(snapshotOf(r), snapshotOf(g))
+}
+
+func TestRestore(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{{}, nil, {}}
+	g, err := NewGame(pl, WithSource(rand.NewSource(1)))
+	is.NoErr(err)
+
+	s := g.Snapshot()
+	_, err = Restore(s, []*Player{{}, {}, {}, nil, nil})
+	is.Equal(err, ErrInvalidSnapshot)
+	_, err = Restore(s, []*Player{{}, nil, {}})
+	is.Equal(err, ErrInvalidSnapshot)
+
+	// an unseeded game shuffles with a new source
+	r, err := Restore(s, []*Player{{}, nil, {}, nil, nil})
+	is.NoErr(err)
+	_, seeded := r.Seed()
+	is.True(!seeded)
+	is.Equal(r.Treasury(), g.Treasury())
+
+	// a failed Restore leaves the players untouched
+	s.Turn = 1
+	untouched := []*Player{{}, nil, {}, nil, nil}
+	_, err = Restore(s, untouched)
+	is.Equal(err, ErrInvalidSnapshot)
+	is.Equal(*untouched[0], Player{})
+	is.Equal(*untouched[2], Player{})
+
+	s.Turn, s.Stack = 0, []SnapshotFrame{{Claim: &SnapshotClaim{AuthorID: 1, Character: CardDuke}}}
+	_, err = Restore(s, []*Player{{}, nil, {}, nil, nil})
+	is.Equal(err, ErrInvalidSnapshot)
+
+	// every phase must hold the state it relies on
+	s.Stack = nil
+	for _, phase := range []uint8{
+		PhaseReaction, PhaseBlock, PhaseBlockChallenge, PhaseProof, PhaseResolve,
+		PhaseExchange, PhaseInfluenceLoss, PhaseShowCard, PhaseExamine,
+		PhaseHandSelection, PhaseExamine + 1,
+	} {
+		s.Phase = phase
+		_, err = Restore(s, []*Player{{}, nil, {}, nil, nil})
+		is.Equal(err, ErrInvalidSnapshot)
+	}
+
+	s.Phase, s.Loss = PhaseInfluenceLoss, &SnapshotLoss{VictimID: 2}
+	r, err = Restore(s, []*Player{{}, nil, {}, nil, nil})
+	is.NoErr(err)
+	is.Equal(r.Pending().Decisions[0].Player, uint8(2))
+
+	s.Version = snapshotVersion + 1
+	_, err = Restore(s, []*Player{{}, nil, {}, nil, nil})
+	is.Equal(err, ErrSnapshotVersion)
+}
+
+func TestRestoreDeclaration(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{{}, {}, {}}
+	g, err := NewGame(pl, WithSeed(1))
+	is.NoErr(err)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionFinancialAid}))
+	is.Equal(g.Phase(), PhaseBlock)
+
+	s := g.Snapshot()
+	is.True(s.Stack[0].Seq != nil)
+
+	r, err := Restore(s, []*Player{{}, {}, {}, nil, nil})
+	is.NoErr(err)
+	is.NoErr(r.Pass(r.players[1]))
+
+	// a declared Action cannot be responded to without its declaration
+	s.Stack[0].Seq = nil
+	_, err = Restore(s, []*Player{{}, {}, {}, nil, nil})
+	is.Equal(err, ErrInvalidSnapshot)
+
+	// a block is declared through its claim
+	is.NoErr(g.Claim(g.players[1], CardDuke))
+	is.Equal(g.Phase(), PhaseBlockChallenge)
+
+	r, err = Restore(g.Snapshot(), []*Player{{}, {}, {}, nil, nil})
+	is.NoErr(err)
+	is.NoErr(r.ClaimChallenge(r.players[0]))
+}
@@ -0,0 +1,102 @@
+package game
+
+// frame is a single entry of the Game's stack. A frame holds a Claim, the
+// Action it allows, or both.
+//
+// The frame at the bottom of the stack is the primary one of the turn.
+// Every frame on top of it is a counter frame that blocks the frame right
+// below it; its action is the counter Action of its claim.
+type frame struct {
+	claim   *claim
+	action  *Action
+	counter bool
+	// seq is the Seq of the entry of the declared action, if any. See
+	// Game.declare.
+	seq *uint64
+}
+
+// top returns the frame on top of the stack, or nil if the stack is
+// empty.
+//
+// top must be called while holding stackMtx.
+func (g *Game) top() *frame {
+	if len(g.stack) == 0 {
+		return nil
+	}
+
+	return g.stack[len(g.stack)-1]
+}
+
+// currentClaim returns the claim of the frame on top of the stack, or nil
+// if there is none.
+//
+// currentClaim must be called while holding stackMtx.
+func (g *Game) currentClaim() *claim {
+	if f := g.top(); f != nil {
+		return f.claim
+	}
+
+	return nil
+}
+
+// primaryAction returns the action of the frame at the bottom of the
+// stack, or nil if there is none.
+//
+// primaryAction must be called while holding stackMtx.
+func (g *Game) primaryAction() *Action {
+	if len(g.stack) == 0 {
+		return nil
+	}
+
+	return g.stack[0].action
+}
+
+// push adds a frame on top of the stack.
+//
+// push must be called while holding stackMtx.
+func (g *Game) push(f *frame) { g.stack = append(g.stack, f) }
+
+// pop removes the frame on top of the stack.
+//
+// pop must be called while holding stackMtx.
+func (g *Game) pop() {
+	if len(g.stack) > 0 {
+		g.stack = g.stack[:len(g.stack)-1]
+	}
+}
+
+// resolveStack resolves the stack from the top to the bottom and returns
+// the frames that take effect in the order they have to be executed; the
+// blocks, and the frame whose Action is executed, if any.
+//
+// Every counter frame cancels the frame right below it. A canceled frame
+// has no effect; neither does its own counter, if any. Counter frames
+// that blocked another frame are returned as is, so that they could be
+// stored in the history along with their claim.
+//
+// resolveStack must be called while holding stackMtx.
+func (g *Game) resolveStack() (blocks []*frame, act *frame) {
+	canceled := false
+	for i := len(g.stack) - 1; i >= 0; i-- {
+		f := g.stack[i]
+		if canceled {
+			canceled = false
+			continue
+		}
+
+		if f.counter {
+			if i > 0 && f.action != nil {
+				blocks = append(blocks, f)
+			}
+
+			canceled = true
+			continue
+		}
+
+		if f.action != nil {
+			act = f
+		}
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameStack(t *testing.T) {
+	is := is.New(t)
+	g := &Game{}
+
+	is.Equal(g.top(), nil)
+	is.Equal(g.currentClaim(), nil)
+	is.Equal(g.primaryAction(), nil)
+	g.pop()
+
+	primary := &frame{claim: &claim{character: CardAssassin}, action: &Action{Kind: ActionCharacter}}
+	counter := &frame{claim: &claim{character: CardContessa}, counter: true}
+
+	g.push(primary)
+	is.Equal(g.top(), primary)
+	g.push(counter)
+	is.Equal(g.top(), counter)
+	is.Equal(g.currentClaim(), counter.claim)
+	is.Equal(g.primaryAction(), primary.action)
+
+	g.pop()
+	is.Equal(g.top(), primary)
+	is.Equal(g.stack, []*frame{primary})
+}
+
+func TestGameResolveStack(t *testing.T) {
+	is := is.New(t)
+	g := &Game{}
+
+	blocks, act := g.resolveStack()
+	is.Equal(len(blocks), 0)
+	is.Equal(act, nil)
+
+	primary := &Action{Kind: ActionFinancialAid}
+	first := &Action{Kind: ActionCharacter, Character: CardDuke}
+	second := &Action{Kind: ActionCharacter, Character: CardDuke}
+
+	g.stack = []*frame{{action: primary}}
+	blocks, act = g.resolveStack()
+	is.Equal(len(blocks), 0)
+	is.Equal(act.action, primary)
+
+	g.stack = append(g.stack, &frame{action: first, counter: true})
+	blocks, act = g.resolveStack()
+	is.Equal(blocks, []*frame{g.stack[1]})
+	is.Equal(act, nil)
+
+	// a block of the block lets the primary action through
+	g.stack = append(g.stack, &frame{action: second, counter: true})
+	blocks, act = g.resolveStack()
+	is.Equal(blocks, []*frame{g.stack[2]})
+	is.Equal(act.action, primary)
+}
+
+func TestGameChallengedBlock(t *testing.T) {
+	g := newTestGame(t, Hand{CardAssassin, CardDuke}, Hand{CardDuke, CardContessa}, Hand{CardCaptain, CardCaptain})
+
+	is := is.New(t)
+
+	g.players[0].Coins = 3
+	one := uint8(1)
+
+	// the assassin's claim is challenged and proven
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.NoErr(g.ClaimChallenge(g.players[2]))
+	_, err := g.ClaimProve(CardAssassin)
+	is.NoErr(err)
+	is.NoErr(g.LoseInfluence(g.players[2], 0))
+	is.Equal(g.Phase(), PhaseAction)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin, AgainstID: &one}))
+	is.Equal(g.Phase(), PhaseBlock)
+
+	// both claims coexist on the stack
+	is.NoErr(g.Claim(g.players[1], CardContessa))
+	is.Equal(len(g.stack), 2)
+	is.Equal(g.stack[0].claim.character, CardAssassin)
+	is.Equal(g.currentClaim().character, CardContessa)
+
+	// the contessa is a bluff; the block is removed and the assassination
+	// goes through.
+	g.players[1].Hand = Hand{CardDuke, CardDuke}
+	is.NoErr(g.ClaimChallenge(g.players[0]))
+	_, err = g.ClaimProve(CardDuke)
+	is.NoErr(err)
+	is.NoErr(g.LoseInfluence(g.players[1], 0))
+	is.Equal(g.Phase(), PhaseResolve)
+	is.Equal(len(g.stack), 1)
+
+	// the last influence is lost without a choice
+	is.NoErr(g.DoAction())
+	is.Equal(g.players[0].Coins, uint8(0))
+	is.Equal(g.Phase(), PhaseTurnEnd)
+	is.True(g.players[1].IsDead())
+	is.Equal(g.stack, nil)
+}
@@ -0,0 +1,89 @@
+package game
+
+// payout returns the amount of coins the treasury pays to the author of
+// the Action under the RuleSet. See Character.Payout. Once the author is
+// known, the amount stops at RuleSet.CoinCap.
+func payout(r RuleSet, a Action) uint8 {
+	amount := uint8(0)
+	switch {
+	case a.Kind == ActionIncome:
+		amount = r.Income
+	case a.Kind == ActionFinancialAid:
+		amount = r.ForeignAid
+	case a.Kind == ActionCharacter:
+		if c, ok := CharacterOf(a.Character); ok {
+			amount = c.Payout(r)
+		}
+	}
+
+	if a.author != nil {
+		return r.gain(a.author.Coins, amount) - a.author.Coins
+	}
+
+	return amount
+}
+
+// Treasury returns the amount of coins left in the Game's treasury.
+//
+// Every coin in the Game is either in the treasury, in the reserve or
+// owned by a player. Income, foreign aid and the Duke are paid by the
+// treasury, while Coups and assassinations are paid to it. See
+// Game.Reserve.
+func (g *Game) Treasury() uint8 {
+	g.treasuryMtx.Lock()
+	defer g.treasuryMtx.Unlock()
+
+	return g.treasury
+}
+
+// withdraw takes coins out of the treasury. It returns false, without
+// taking anything, if the treasury is short.
+func (g *Game) withdraw(coins uint8) bool {
+	g.treasuryMtx.Lock()
+	defer g.treasuryMtx.Unlock()
+
+	if g.treasury < coins {
+		return false
+	}
+
+	g.treasury -= coins
+	return true
+}
+
+// settle moves the coins of an Action between the treasury and its
+// author. See payout and actionCost. Conversions are paid to the reserve
+// instead, and an Embezzlement moves the reserve to its author. See
+// RuleSet.EmbezzleAction.
+//
+// Do note: settle must be called before the Action is executed; the
+//          payout depends on the coins its author holds. See
+//          RuleSet.CoinCap.
+func (g *Game) settle(a Action) {
+	g.treasuryMtx.Lock()
+	defer g.treasuryMtx.Unlock()
+
+	switch a.Kind {
+	case ActionConvert:
+		g.reserve += actionCost(g.rules, a)
+	case ActionEmbezzle:
+		a.author.Coins, g.reserve = g.rules.EmbezzleAction(a.author.Coins, g.reserve)
+	default:
+		g.treasury = g.treasury - payout(g.rules, a) + actionCost(g.rules, a)
+	}
+}
+
+// forfeit charges the author of a blocked Action its cost. Like in the
+// base game, the coins spent on a blocked assassination are lost to the
+// treasury.
+func (g *Game) forfeit(a Action) {
+	cost := actionCost(g.rules, a)
+	if a.author == nil || a.author.Coins < cost {
+		return
+	}
+
+	g.treasuryMtx.Lock()
+	defer g.treasuryMtx.Unlock()
+
+	a.author.Coins -= cost
+	g.treasury += cost
+}
@@ -0,0 +1,115 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestPayout(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(payout(DefaultRules(), Action{Kind: ActionIncome}), uint8(1))
+	is.Equal(payout(DefaultRules(), Action{Kind: ActionFinancialAid}), uint8(2))
+	is.Equal(payout(DefaultRules(), Action{Kind: ActionCharacter, Character: CardDuke}), uint8(3))
+	is.Equal(payout(DefaultRules(), Action{Kind: ActionCharacter, Character: CardCaptain}), uint8(0))
+	is.Equal(payout(DefaultRules(), Action{Kind: ActionCoup}), uint8(0))
+}
+
+func TestGameWithdraw(t *testing.T) {
+	is := is.New(t)
+	g := &Game{treasury: 3}
+
+	is.True(!g.withdraw(4))
+	is.Equal(g.Treasury(), uint8(3))
+	is.True(g.withdraw(3))
+	is.Equal(g.Treasury(), uint8(0))
+}
+
+func TestGameSettle(t *testing.T) {
+	is := is.New(t)
+	g := &Game{treasury: 10, rules: DefaultRules()}
+
+	g.settle(Action{Kind: ActionCharacter, Character: CardDuke})
+	is.Equal(g.Treasury(), uint8(7))
+	g.settle(Action{Kind: ActionCoup})
+	is.Equal(g.Treasury(), uint8(14))
+	g.settle(Action{Kind: ActionCharacter, Character: CardCaptain})
+	is.Equal(g.Treasury(), uint8(14))
+
+	// conversions are paid to the reserve, which embezzlements empty
+	g.settle(Action{Kind: ActionConvert})
+	g.settle(Action{Kind: ActionConvert, AgainstID: newUint8(1)})
+	is.Equal(g.Treasury(), uint8(14))
+	is.Equal(g.Reserve(), DefaultRules().ConvertCost+DefaultRules().ConvertOtherCost)
+
+	author := &Player{Coins: 1}
+	g.settle(Action{Kind: ActionEmbezzle, author: author})
+	is.Equal(author.Coins, 1+DefaultRules().ConvertCost+DefaultRules().ConvertOtherCost)
+	is.Equal(g.Reserve(), uint8(0))
+}
+
+func TestGameTreasury(t *testing.T) {
+	g := newTestGame(t, Hand{CardDuke, CardAssassin}, Hand{CardDuke, CardContessa})
+
+	is := is.New(t)
+
+	total := func() uint8 {
+		return g.Treasury() + g.players[0].Coins + g.players[1].Coins
+	}
+	is.Equal(total(), defaultTreasury)
+
+	g.treasury, g.players[0].Coins = 2, 8
+
+	// the treasury cannot pay for the Duke
+	is.Equal(g.Claim(g.players[0], CardDuke), ErrInsufficientTreasury)
+
+	// nor once it runs short after the claim
+	g.treasury = 3
+	is.NoErr(g.Claim(g.players[0], CardDuke))
+	is.NoErr(g.ClaimPass())
+	g.treasury = 2
+	is.Equal(g.LegalMoves(0), []Move{})
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardDuke}), ErrInsufficientTreasury)
+
+	g.endTurn()
+	g.setPhase(PhaseAction)
+	is.Equal(len(g.LegalMoves(0)), 6)
+
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionFinancialAid}))
+	is.NoErr(g.Pass(g.players[1]))
+	is.NoErr(g.DoAction())
+	is.Equal(g.Treasury(), uint8(0))
+	is.Equal(g.players[0].Coins, uint8(10))
+
+	g.NextTurn()
+	is.Equal(g.Action(Action{AuthorID: 1, Kind: ActionIncome}), ErrInsufficientTreasury)
+	g.NextTurn()
+
+	// a coup is paid to the treasury
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCoup, AgainstID: newUint8(1)}))
+	is.NoErr(g.DoAction())
+	is.Equal(g.Treasury(), DefaultRules().CoupCost)
+	is.Equal(g.players[0].Coins, uint8(3))
+}
+
+func TestGameBlockedAssassination(t *testing.T) {
+	g := newTestGame(t, Hand{CardAssassin, CardDuke}, Hand{CardContessa, CardDuke})
+
+	is := is.New(t)
+
+	g.players[0].Coins = DefaultRules().AssassinCost
+	treasury := g.Treasury()
+
+	is.NoErr(g.Claim(g.players[0], CardAssassin))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.Action(Action{AuthorID: 0, Kind: ActionCharacter, Character: CardAssassin, AgainstID: newUint8(1)}))
+	is.NoErr(g.Claim(g.players[1], CardContessa))
+	is.NoErr(g.ClaimPass())
+	is.NoErr(g.DoAction())
+
+	// the coins are spent even though the Contessa blocked the assassin
+	is.Equal(g.players[0].Coins, uint8(0))
+	is.Equal(g.Treasury(), treasury+DefaultRules().AssassinCost)
+	is.Equal(g.players[1].Hand, Hand{CardContessa, CardDuke})
+}
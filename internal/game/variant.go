@@ -0,0 +1,143 @@
+package game
+
+// dealSelection deals one of every character to both players of the
+// two-player variant and shuffles the third set into the deck. Every set
+// is stored in the history as an ActionDeal. The starting player, first,
+// is given one coin instead of RuleSet.StartingCoins.
+//
+// Do note: Both players hold an empty Hand until they have picked their
+//          card, so Player.IsDead is meaningless until then. See
+//          Game.SelectHand.
+func (g *Game) dealSelection(first int) {
+	g.deck = shuffleCards(newDeck(g.characters(), 1), g.rand)
+	g.selection = make([][]uint8, g.max)
+	g.picks = make([]uint8, g.max)
+
+	for k, v := range g.players[:g.max] {
+		if v == nil {
+			continue
+		}
+
+		v.Hand, v.Coins = Hand{}, g.rules.StartingCoins
+		if k == first {
+			v.Coins = g.rules.variantCoins()
+		}
+
+		g.selection[k] = newDeck(g.characters(), 1)
+		g.addActionToHistory(Action{
+			AuthorID: uint8(k),
+			Kind:     ActionDeal,
+			Cards:    newDeck(g.characters(), 1),
+		})
+	}
+
+	g.setPhase(PhaseHandSelection)
+}
+
+// selectionFor returns the set the player at index picks a card out of.
+// It returns nil if the player at index has already picked their card or
+// if the Game isn't waiting on them.
+func (g *Game) selectionFor(index int) []uint8 {
+	g.selectionMtx.Lock()
+	defer g.selectionMtx.Unlock()
+
+	if index < 0 || index >= len(g.selection) || g.picks[index] != CardEmpty {
+		return nil
+	}
+
+	return append([]uint8(nil), g.selection[index]...)
+}
+
+// SelectHand is a function that lets a player of the two-player variant
+// pick the character they keep out of the set they were dealt. Once both
+// players have picked, the rest of their sets is shuffled into the deck,
+// both players draw their second card and the first turn starts. Both
+// hands are stored in the history as an ActionHandSelection.
+//
+// SelectHand returns ErrInvalidPhase outside of PhaseHandSelection,
+// ErrHandSelected if the player has already picked and
+// ErrInvalidCharacter if character isn't part of their set.
+func (g *Game) SelectHand(player *Player, character uint8) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	g.selectionMtx.Lock()
+	defer g.selectionMtx.Unlock()
+
+	if g.Phase() != PhaseHandSelection {
+		return ErrInvalidPhase
+	}
+
+	index := findPlayerByPntr(g.players, player)
+	if index < 0 || g.selection[index] == nil {
+		return ErrInvalidPlayer
+	} else if g.picks[index] != CardEmpty {
+		return ErrHandSelected
+	}
+
+	found := false
+	for _, v := range g.selection[index] {
+		found = found || v == character
+	}
+
+	if !found {
+		return ErrInvalidCharacter
+	}
+
+	g.picks[index] = character
+	for k, set := range g.selection {
+		if set != nil && g.picks[k] == CardEmpty {
+			return nil
+		}
+	}
+
+	g.finishSelection()
+	return nil
+}
+
+// finishSelection shuffles the cards that weren't picked into the deck,
+// deals the second card of both players and starts the first turn.
+//
+// finishSelection must be called while holding selectionMtx.
+func (g *Game) finishSelection() {
+	for k, set := range g.selection {
+		if set == nil {
+			continue
+		}
+
+		rest := []uint8{}
+		picked := false
+		for _, v := range set {
+			if v == g.picks[k] && !picked {
+				picked = true
+				continue
+			}
+
+			rest = append(rest, v)
+		}
+
+		g.ReturnCards(rest)
+	}
+
+	g.Shuffle()
+
+	for k, set := range g.selection {
+		if set == nil {
+			continue
+		}
+
+		// the empty Hand of PhaseHandSelection could have been mistaken
+		// for a dead player's.
+		drawn := g.DrawCards(1)
+		g.players[k].Hand, g.players[k].dead = Hand{g.picks[k], drawn[0]}, false
+		g.addActionToHistory(Action{
+			AuthorID: uint8(k),
+			Kind:     ActionHandSelection,
+			Cards:    []uint8{g.picks[k], drawn[0]},
+		})
+	}
+
+	g.selection, g.picks = nil, nil
+	g.setPhase(PhaseAction)
+}
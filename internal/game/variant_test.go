@@ -0,0 +1,106 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestGameDealSelection(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{nil, {Hand: Hand{CardDuke, CardDuke}}, nil, {}}
+	g, err := NewGame(pl, WithSeed(1), WithTwoPlayerVariant())
+	is.NoErr(err)
+
+	is.Equal(g.Phase(), PhaseHandSelection)
+	is.Equal(len(g.deck), 5)
+	is.Equal(g.Treasury(), defaultTreasury-DefaultRules().StartingCoins-variantStartingCoins)
+
+	is.Equal(pl[1].Coins, variantStartingCoins)
+	is.Equal(pl[3].Coins, DefaultRules().StartingCoins)
+	is.Equal(pl[1].Hand, Hand{})
+
+	is.Equal(len(g.history), 2)
+	for k, index := range []uint8{1, 3} {
+		is.Equal(g.history[k].Action, Action{AuthorID: index, Kind: ActionDeal, Cards: newDeck(g.characters(), 1)})
+		is.Equal(g.selectionFor(int(index)), newDeck(g.characters(), 1))
+	}
+
+	_, err = NewGame([5]*Player{{}, {}, {}}, WithTwoPlayerVariant())
+	is.Equal(err, ErrInvalidVariant)
+}
+
+func TestGameSelectionFor(t *testing.T) {
+	is := is.New(t)
+
+	g, err := NewGame([5]*Player{{}, {}}, WithTwoPlayerVariant())
+	is.NoErr(err)
+
+	is.Equal(g.selectionFor(-1), nil)
+	is.Equal(g.selectionFor(2), nil)
+
+	set := g.selectionFor(0)
+	set[0] = CardEmpty
+	is.Equal(g.selectionFor(0), newDeck(g.characters(), 1))
+
+	is.NoErr(g.SelectHand(g.players[0], CardDuke))
+	is.Equal(g.selectionFor(0), nil)
+	is.Equal(g.selectionFor(1), newDeck(g.characters(), 1))
+}
+
+func TestGameSelectHand(t *testing.T) {
+	is := is.New(t)
+
+	pl := [5]*Player{{}, {}}
+	g, err := NewGame(pl, WithSeed(3), WithTwoPlayerVariant())
+	is.NoErr(err)
+
+	// nothing else can happen before both players have picked
+	is.Equal(g.Claim(pl[0], CardDuke), ErrInvalidPhase)
+	is.Equal(g.Action(Action{AuthorID: 0, Kind: ActionIncome}), ErrInvalidPhase)
+	g.NextTurn()
+	is.Equal(g.Phase(), PhaseHandSelection)
+	is.True(pl[0].IsDead())
+
+	is.Equal(g.SelectHand(&Player{}, CardDuke), ErrInvalidPlayer)
+	is.Equal(g.SelectHand(pl[0], CardEmpty), ErrInvalidCharacter)
+
+	is.NoErr(g.SelectHand(pl[0], CardDuke))
+	is.Equal(g.SelectHand(pl[0], CardCaptain), ErrHandSelected)
+	is.Equal(g.Phase(), PhaseHandSelection)
+	is.Equal(pl[0].Hand, Hand{})
+
+	is.NoErr(g.SelectHand(pl[1], CardContessa))
+	is.Equal(g.Phase(), PhaseAction)
+	is.Equal(g.SelectHand(pl[1], CardContessa), ErrInvalidPhase)
+	is.True(!pl[0].IsDead())
+
+	is.Equal(pl[0].Hand[0], CardDuke)
+	is.Equal(pl[1].Hand[0], CardContessa)
+	is.True(IsValidCard(pl[0].Hand[1]) && IsValidCard(pl[1].Hand[1]))
+	is.Equal(len(g.deck), 11)
+
+	turn, err := g.TurnGet()
+	is.NoErr(err)
+	is.Equal(turn, 0)
+
+	for k, index := range []uint8{0, 1} {
+		is.Equal(g.history[2+k].Action, Action{
+			AuthorID: index,
+			Kind:     ActionHandSelection,
+			Cards:    pl[index].Hand[:],
+		})
+	}
+	is.Equal(g.HistoryFor(1)[2].Cards, nil)
+
+	// every card is either in the deck or in a hand
+	count := map[uint8]int{}
+	for _, v := range append(append([]uint8{}, g.deck...), pl[0].Hand[0], pl[0].Hand[1], pl[1].Hand[0], pl[1].Hand[1]) {
+		count[v]++
+	}
+
+	for _, v := range normalDeck {
+		is.Equal(count[v], 3)
+	}
+}